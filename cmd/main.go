@@ -7,9 +7,13 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"pipedrive_api_service/internal/idempotency"
+	"pipedrive_api_service/internal/jobs"
+	"pipedrive_api_service/internal/resource"
 	"pipedrive_api_service/internal/routes"
 	"pipedrive_api_service/internal/upstream"
 )
@@ -17,9 +21,36 @@ import (
 func main() {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/pipedrive/pipelines", routes.PipelinesHandler)
+	mux.HandleFunc("/pipedrive/stages", routes.StagesHandler)
 	mux.HandleFunc("/pipedrive/organizations", routes.OrganizationsHandler)
 	mux.HandleFunc("/pipedrive/deals", routes.DealsHandler)
 
+	// Rotas por id; /pipedrive/deals/products precisa de padrões com método para
+	// não conflitar com "GET /pipedrive/deals/{id}"
+	for _, method := range []string{"GET", "PUT", "PATCH", "DELETE"} {
+		mux.HandleFunc(method+" /pipedrive/deals/{id}", routes.DealHandler)
+		mux.HandleFunc(method+" /pipedrive/organizations/{id}", routes.OrganizationHandler)
+	}
+	for _, method := range []string{"GET", "POST", "PUT", "DELETE"} {
+		mux.HandleFunc(method+" /pipedrive/deals/products", routes.DealProductsHandler)
+	}
+
+	mux.HandleFunc("/pipedrive/persons", routes.PersonsHandler)
+	mux.HandleFunc("/pipedrive/activities", routes.ActivitiesHandler)
+	mux.HandleFunc("/pipedrive/activities/done", routes.ActivitiesDoneHandler)
+	mux.HandleFunc("/pipedrive/notes", routes.NotesHandler)
+	mux.HandleFunc("/pipedrive/products", routes.ProductsHandler)
+	mux.HandleFunc("/pipedrive/users", routes.UsersHandler)
+	mux.HandleFunc("/pipedrive/users/me", routes.UsersMeHandler)
+	mux.HandleFunc("/pipedrive/leads", routes.LeadsHandler)
+	mux.HandleFunc("/pipedrive/leads/convert", routes.LeadsConvertHandler)
+	mux.HandleFunc("/pipedrive/search", routes.SearchHandler)
+	mux.HandleFunc("/pipedrive/raw/{path...}", routes.RawHandler)
+	mux.HandleFunc("/pipedrive/fields/{entity}", routes.FieldsHandler)
+	mux.HandleFunc("/pipedrive/admin/fields/refresh", routes.FieldsRefreshHandler)
+	mux.HandleFunc("/pipedrive/jobs", routes.JobsHandler)
+	mux.HandleFunc("/pipedrive/jobs/{id}", routes.JobHandler)
+
 	workers := 4
 	queueSize := 1024
 
@@ -34,12 +65,90 @@ func main() {
 		}
 	}
 
+	idempotencyTTL := idempotency.DefaultTTL
+	if ttl := os.Getenv("PIPEDRIVE_IDEMPOTENCY_TTL"); ttl != "" {
+		if parsed, err := time.ParseDuration(ttl); err == nil && parsed > 0 {
+			idempotencyTTL = parsed
+		}
+	}
+	idempotencyStore := idempotency.NewStore(idempotencyTTL)
+
+	if ttl := os.Getenv("PIPEDRIVE_FIELD_CACHE_TTL"); ttl != "" {
+		if parsed, err := time.ParseDuration(ttl); err == nil && parsed > 0 {
+			resource.SetFieldCacheTTL(parsed)
+		}
+	}
+	if fanOut := os.Getenv("PIPEDRIVE_DETAIL_CONCURRENCY"); fanOut != "" {
+		if parsed, err := strconv.Atoi(fanOut); err == nil && parsed > 0 {
+			resource.SetDetailConcurrency(parsed)
+		}
+	}
+	if bulk := os.Getenv("PIPEDRIVE_BULK_CONCURRENCY"); bulk != "" {
+		if parsed, err := strconv.Atoi(bulk); err == nil && parsed > 0 {
+			resource.SetBulkConcurrency(parsed)
+		}
+	}
+	if deadline := os.Getenv("PIPEDRIVE_BULK_DEADLINE"); deadline != "" {
+		if parsed, err := time.ParseDuration(deadline); err == nil && parsed > 0 {
+			resource.SetBulkDeadline(parsed)
+		}
+	}
+
+	routes.SetRawPathRules(routes.RawPathRules{
+		Allow: strings.Split(os.Getenv("PIPEDRIVE_RAW_ALLOW"), ","),
+		Deny:  strings.Split(os.Getenv("PIPEDRIVE_RAW_DENY"), ","),
+	})
+
 	broker := upstream.NewUpstreamBroker(workers, queueSize)
 	upstream.SetGlobalBroker(broker)
 
+	jobWorkers, jobQueue, jobTTL := jobs.DefaultWorkers, jobs.DefaultQueueSize, jobs.DefaultTTL
+	if w := os.Getenv("PIPEDRIVE_JOB_WORKERS"); w != "" {
+		if parsed, err := strconv.Atoi(w); err == nil && parsed > 0 {
+			jobWorkers = parsed
+		}
+	}
+	if qs := os.Getenv("PIPEDRIVE_JOB_QUEUE"); qs != "" {
+		if parsed, err := strconv.Atoi(qs); err == nil && parsed > 0 {
+			jobQueue = parsed
+		}
+	}
+	if ttl := os.Getenv("PIPEDRIVE_JOB_TTL"); ttl != "" {
+		if parsed, err := time.ParseDuration(ttl); err == nil && parsed > 0 {
+			jobTTL = parsed
+		}
+	}
+	jobManager := jobs.NewManager(jobWorkers, jobQueue, jobTTL)
+	jobs.SetGlobalManager(jobManager)
+
+	// optional write-ahead log: writes still queued in the broker and async
+	// jobs that did not finish survive a restart and come back as recovered jobs
+	var wal *upstream.WAL
+	if walPath := os.Getenv("PIPEDRIVE_WAL_PATH"); walPath != "" {
+		maxAge := jobs.DefaultReplayMaxAge
+		if age := os.Getenv("PIPEDRIVE_WAL_MAX_AGE"); age != "" {
+			if parsed, err := time.ParseDuration(age); err == nil && parsed > 0 {
+				maxAge = parsed
+			}
+		}
+
+		var backlog upstream.Backlog
+		var err error
+		wal, backlog, err = upstream.OpenWAL(walPath)
+		if err != nil {
+			log.Fatalf("wal: %v", err)
+		}
+		broker.SetWAL(wal)
+		jobManager.SetWAL(wal)
+		if !backlog.Empty() {
+			ids := jobManager.Recover(backlog, idempotencyStore, maxAge)
+			log.Printf("wal: recovering %d pending writes and %d async jobs as jobs %v", len(backlog.Tasks), len(backlog.Jobs), ids)
+		}
+	}
+
 	server := &http.Server{
 		Addr:         ":9010",
-		Handler:      mux,
+		Handler:      idempotencyStore.Middleware(mux),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -67,8 +176,18 @@ func main() {
 		log.Println("http server stopped")
 	}
 
+	// cancel async jobs before the broker goes away
+	log.Println("stopping async jobs")
+	jobManager.Stop()
+
 	// stop broker
 	log.Println("shutting down broker")
 	broker.Stop()
 	log.Println("broker stopped")
+
+	if wal != nil {
+		if err := wal.Close(); err != nil {
+			log.Printf("wal close error: %v", err)
+		}
+	}
 }
@@ -103,3 +103,20 @@ func (c *PipedriveClient) DoWithBody(ctx context.Context, method utils.HTTPMetho
 	resp, b, rate, err := broker.Execute(ctx, string(method), u.String(), headers, bodyBytes, 3)
 	return resp, b, rate, err
 }
+
+// Replay sends a write recovered from the broker WAL, adding the api_token
+// back to its URL.
+func (c *PipedriveClient) Replay(ctx context.Context, task upstream.PendingTask) (*http.Response, []byte, *utils.RateLimitInfo, error) {
+	broker := upstream.GlobalBroker()
+	if broker == nil {
+		return nil, nil, nil, fmt.Errorf("replay: broker not running")
+	}
+	u, err := url.Parse(task.URL)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("replay: invalid url: %w", err)
+	}
+	q := u.Query()
+	q.Set("api_token", c.token)
+	u.RawQuery = q.Encode()
+	return broker.Resume(ctx, task, u.String())
+}
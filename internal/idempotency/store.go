@@ -0,0 +1,235 @@
+// Package idempotency implementa o cabeçalho Idempotency-Key para as rotas de
+// escrita: a primeira requisição com uma chave é executada e sua resposta final
+// é guardada; repetições com o mesmo corpo recebem a resposta guardada.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"pipedrive_api_service/internal/upstream"
+	"pipedrive_api_service/internal/utils"
+)
+
+const DefaultTTL = 24 * time.Hour
+
+// Response é uma resposta final guardada para replay. Header guarda os
+// cabeçalhos definidos pelo handler (e.g. Content-Type e o Location do 202 de
+// um job assíncrono).
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+type entry struct {
+	fingerprint string
+	response    *Response // nil enquanto a primeira requisição está em andamento
+	expiresAt   time.Time
+}
+
+type Store struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*entry
+}
+
+// NewStore cria um store em memória; respostas expiram após 'ttl'.
+func NewStore(ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{
+		ttl:     ttl,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Fingerprint identifica a requisição: método, caminho, query e corpo.
+func Fingerprint(method, path, rawQuery string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n", method, path, rawQuery)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Begin reserva a chave. Retorna a resposta guardada quando já existe uma
+// para o mesmo fingerprint; ErrMismatch quando a chave foi usada com outra
+// requisição; ErrInProgress quando a primeira ainda não terminou.
+func (s *Store) Begin(key, fingerprint string) (*Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.purgeLocked(now)
+
+	if e, ok := s.entries[key]; ok {
+		if e.fingerprint != fingerprint {
+			return nil, ErrMismatch
+		}
+		if e.response == nil {
+			return nil, ErrInProgress
+		}
+		return e.response, nil
+	}
+
+	s.entries[key] = &entry{
+		fingerprint: fingerprint,
+		expiresAt:   now.Add(s.ttl),
+	}
+	return nil, nil
+}
+
+// Complete guarda a resposta final da chave reservada em Begin
+func (s *Store) Complete(key string, resp *Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		e.response = resp
+		e.expiresAt = time.Now().Add(s.ttl)
+	}
+}
+
+// Release libera a chave sem guardar resposta (e.g. falha 5xx), permitindo
+// que uma nova tentativa execute a operação
+func (s *Store) Release(key string) {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+}
+
+func (s *Store) purgeLocked(now time.Time) {
+	for k, e := range s.entries {
+		if e.response != nil && now.After(e.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+}
+
+var (
+	ErrMismatch   = errors.New("idempotency key was already used with a different request")
+	ErrInProgress = errors.New("a request with this Idempotency-Key is still in progress")
+)
+
+// recorder copia a resposta enviada ao cliente para que ela possa ser guardada
+type recorder struct {
+	http.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(p []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.buf.Write(p)
+	return rec.ResponseWriter.Write(p)
+}
+
+// handlerHeaders retorna os cabeçalhos que o handler definiu ou alterou
+func handlerHeaders(preset, final http.Header) http.Header {
+	out := http.Header{}
+	for name, values := range final {
+		if !slices.Equal(preset[name], values) {
+			out[name] = append([]string(nil), values...)
+		}
+	}
+	return out
+}
+
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Middleware aplica o Idempotency-Key às requisições de escrita. Sem o
+// cabeçalho, a requisição segue normalmente.
+func (s *Store) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(utils.HeaderIdempotencyKey)
+		if key == "" || !isWrite(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			utils.JSONError(w, http.StatusBadRequest, "failed to read request body", nil)
+			return
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := Fingerprint(r.Method, r.URL.Path, r.URL.RawQuery, body)
+		stored, err := s.Begin(key, fingerprint)
+		switch err {
+		case ErrMismatch:
+			utils.JSONError(w, http.StatusUnprocessableEntity, err.Error(), nil)
+			return
+		case ErrInProgress:
+			utils.JSONError(w, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		if stored != nil {
+			for name, values := range stored.Header {
+				w.Header()[name] = append([]string(nil), values...)
+			}
+			w.Header().Set(utils.HeaderIdempotentReplayed, "true")
+			w.WriteHeader(stored.Status)
+			_, _ = w.Write(stored.Body)
+			return
+		}
+
+		// A operação segue até o fim mesmo se o cliente desistir (timeout),
+		// para que a nova tentativa receba o resultado real. A chave acompanha
+		// as escritas no WAL do broker, para o replay após um restart.
+		ctx := upstream.WithOrigin(context.WithoutCancel(r.Context()), upstream.TaskOrigin{
+			Operation:      r.Method + " " + r.URL.Path,
+			IdempotencyKey: key,
+			Fingerprint:    fingerprint,
+		})
+		r = r.WithContext(ctx)
+
+		// cabeçalhos já definidos antes do handler (e.g. X-Request-ID) são
+		// da requisição atual e não entram no replay
+		preset := w.Header().Clone()
+		rec := &recorder{ResponseWriter: w}
+		defer func() {
+			if p := recover(); p != nil {
+				s.Release(key)
+				panic(p)
+			}
+		}()
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 || rec.status >= 500 {
+			s.Release(key)
+			return
+		}
+		s.Complete(key, &Response{
+			Status: rec.status,
+			Header: handlerHeaders(preset, rec.Header()),
+			Body:   rec.buf.Bytes(),
+		})
+	})
+}
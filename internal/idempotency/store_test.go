@@ -0,0 +1,223 @@
+package idempotency
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"pipedrive_api_service/internal/utils"
+)
+
+func TestStoreBeginComplete(t *testing.T) {
+	s := NewStore(time.Hour)
+
+	stored, err := s.Begin("k1", "fp")
+	if err != nil || stored != nil {
+		t.Fatalf("first Begin = %v, %v; want nil, nil", stored, err)
+	}
+
+	if _, err := s.Begin("k1", "fp"); !errors.Is(err, ErrInProgress) {
+		t.Fatalf("Begin while in progress = %v; want ErrInProgress", err)
+	}
+	if _, err := s.Begin("k1", "other"); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("Begin with another fingerprint = %v; want ErrMismatch", err)
+	}
+
+	resp := &Response{Status: http.StatusCreated, Body: []byte("ok")}
+	s.Complete("k1", resp)
+
+	stored, err = s.Begin("k1", "fp")
+	if err != nil || stored != resp {
+		t.Fatalf("Begin after Complete = %v, %v; want the stored response", stored, err)
+	}
+	if _, err := s.Begin("k1", "other"); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("Begin with another fingerprint after Complete = %v; want ErrMismatch", err)
+	}
+}
+
+func TestStoreRelease(t *testing.T) {
+	s := NewStore(time.Hour)
+
+	if _, err := s.Begin("k1", "fp"); err != nil {
+		t.Fatal(err)
+	}
+	s.Release("k1")
+
+	// a chave liberada pode ser usada até com outra requisição
+	stored, err := s.Begin("k1", "other")
+	if err != nil || stored != nil {
+		t.Fatalf("Begin after Release = %v, %v; want nil, nil", stored, err)
+	}
+}
+
+func TestStoreExpiry(t *testing.T) {
+	s := NewStore(time.Millisecond)
+
+	if _, err := s.Begin("k1", "fp"); err != nil {
+		t.Fatal(err)
+	}
+	s.Complete("k1", &Response{Status: http.StatusOK})
+	time.Sleep(5 * time.Millisecond)
+
+	stored, err := s.Begin("k1", "other")
+	if err != nil || stored != nil {
+		t.Fatalf("Begin after expiry = %v, %v; want nil, nil", stored, err)
+	}
+}
+
+func TestStoreKeepsInProgressEntries(t *testing.T) {
+	s := NewStore(time.Millisecond)
+
+	if _, err := s.Begin("k1", "fp"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := s.Begin("k1", "fp"); !errors.Is(err, ErrInProgress) {
+		t.Fatalf("Begin = %v; want ErrInProgress even after the TTL", err)
+	}
+}
+
+func request(method, body, key string) *http.Request {
+	r := httptest.NewRequest(method, "/pipedrive/deals", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(utils.HeaderIdempotencyKey, key)
+	}
+	return r
+}
+
+func TestMiddlewareReplay(t *testing.T) {
+	s := NewStore(time.Hour)
+	calls := 0
+	handler := s.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set(utils.HeaderContentType, utils.ContentTypeJSON)
+		w.Header().Set(utils.HeaderLocation, "/pipedrive/jobs/job_1")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write(body)
+	}))
+	// cabeçalho definido antes do handler, como o de um middleware externo
+	withRequestID := func(id string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(utils.HeaderXRequestID, id)
+			handler.ServeHTTP(w, r)
+		})
+	}
+
+	first := httptest.NewRecorder()
+	withRequestID("req-1").ServeHTTP(first, request(http.MethodPost, `{"title":"A"}`, "k1"))
+	if first.Code != http.StatusAccepted || first.Body.String() != `{"title":"A"}` {
+		t.Fatalf("first response = %d %q", first.Code, first.Body.String())
+	}
+
+	replay := httptest.NewRecorder()
+	withRequestID("req-2").ServeHTTP(replay, request(http.MethodPost, `{"title":"A"}`, "k1"))
+	if calls != 1 {
+		t.Fatalf("handler called %d times; want 1", calls)
+	}
+	if replay.Code != http.StatusAccepted || replay.Body.String() != `{"title":"A"}` {
+		t.Fatalf("replay = %d %q", replay.Code, replay.Body.String())
+	}
+	for name, want := range map[string]string{
+		utils.HeaderIdempotentReplayed: "true",
+		utils.HeaderLocation:           "/pipedrive/jobs/job_1",
+		utils.HeaderContentType:        utils.ContentTypeJSON,
+		utils.HeaderXRequestID:         "req-2",
+	} {
+		if got := replay.Header().Get(name); got != want {
+			t.Errorf("replay header %s = %q; want %q", name, got, want)
+		}
+	}
+}
+
+func TestMiddlewareMismatch(t *testing.T) {
+	s := NewStore(time.Hour)
+	handler := s.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), request(http.MethodPost, `{"title":"A"}`, "k1"))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, request(http.MethodPost, `{"title":"B"}`, "k1"))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d; want 422", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), ErrMismatch.Error()) {
+		t.Fatalf("body = %s; want the mismatch error", rec.Body.String())
+	}
+}
+
+func TestMiddlewareInProgress(t *testing.T) {
+	s := NewStore(time.Hour)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := s.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		handler.ServeHTTP(httptest.NewRecorder(), request(http.MethodPost, `{}`, "k1"))
+	}()
+	<-started
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, request(http.MethodPost, `{}`, "k1"))
+	close(release)
+	wg.Wait()
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d; want 409", rec.Code)
+	}
+}
+
+func TestMiddlewareReleasesOnServerError(t *testing.T) {
+	s := NewStore(time.Hour)
+	calls := 0
+	handler := s.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), request(http.MethodPost, `{}`, "k1"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, request(http.MethodPost, `{}`, "k1"))
+
+	if calls != 2 || rec.Code != http.StatusCreated {
+		t.Fatalf("calls = %d, status = %d; want a second execution with 201", calls, rec.Code)
+	}
+	if rec.Header().Get(utils.HeaderIdempotentReplayed) != "" {
+		t.Fatal("a retry after a 5xx must not be a replay")
+	}
+}
+
+func TestMiddlewareIgnoresReadsAndMissingKey(t *testing.T) {
+	s := NewStore(time.Hour)
+	calls := 0
+	handler := s.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+
+	for i := 0; i < 2; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), request(http.MethodGet, "", "k1"))
+		handler.ServeHTTP(httptest.NewRecorder(), request(http.MethodPost, `{}`, ""))
+	}
+	if calls != 4 {
+		t.Fatalf("handler called %d times; want 4", calls)
+	}
+}
@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"pipedrive_api_service/internal/upstream"
+)
+
+type State string
+
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateCompleted State = "completed"
+	StateCancelled State = "cancelled"
+)
+
+type Progress struct {
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// Status é a visão de um job retornada por /pipedrive/jobs/{id}
+type Status struct {
+	ID         string                 `json:"id"`
+	Operation  string                 `json:"operation"`
+	State      State                  `json:"state"`
+	Recovered  bool                   `json:"recovered,omitempty"`
+	Outcome    string                 `json:"outcome,omitempty"`
+	Progress   Progress               `json:"progress"`
+	Results    map[string]interface{} `json:"results,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+	StartedAt  *time.Time             `json:"started_at,omitempty"`
+	FinishedAt *time.Time             `json:"finished_at,omitempty"`
+}
+
+type Job struct {
+	mu        sync.Mutex
+	id        string
+	operation string
+	origin    upstream.TaskOrigin
+	state     State
+	recovered bool
+	outcome   string
+	progress  Progress
+	results   map[string]interface{}
+
+	createdAt time.Time
+	startedAt time.Time
+	doneAt    time.Time
+
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	run    RunFunc
+	wal    *upstream.WAL
+}
+
+func (j *Job) ID() string { return j.id }
+
+// Record grava o resultado de um item; ok conta como sucesso.
+func (j *Job) Record(key string, result interface{}, ok bool) {
+	j.mu.Lock()
+	j.results[key] = result
+	j.progress.Processed++
+	if ok {
+		j.progress.Succeeded++
+	} else {
+		j.progress.Failed++
+	}
+	j.mu.Unlock()
+
+	if j.wal != nil {
+		j.wal.JobItem(j.id, key, ok)
+	}
+}
+
+// Status retorna uma cópia do estado; 'withResults' inclui os resultados por item.
+func (j *Job) Status(withResults bool) Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	s := Status{
+		ID:        j.id,
+		Operation: j.operation,
+		State:     j.state,
+		Recovered: j.recovered,
+		Outcome:   j.outcome,
+		Progress:  j.progress,
+		CreatedAt: j.createdAt,
+	}
+	if !j.startedAt.IsZero() {
+		started := j.startedAt
+		s.StartedAt = &started
+	}
+	if !j.doneAt.IsZero() {
+		finished := j.doneAt
+		s.FinishedAt = &finished
+	}
+	if withResults {
+		s.Results = make(map[string]interface{}, len(j.results))
+		for k, v := range j.results {
+			s.Results[k] = v
+		}
+	}
+	return s
+}
+
+func (j *Job) execute() {
+	defer j.cancel(nil)
+	defer j.logDone()
+
+	j.mu.Lock()
+	if j.ctx.Err() != nil {
+		j.state = StateCancelled
+		j.doneAt = time.Now()
+		j.mu.Unlock()
+		return
+	}
+	j.state = StateRunning
+	j.startedAt = time.Now()
+	j.mu.Unlock()
+
+	ctx := upstream.WithOrigin(j.ctx, j.origin)
+	outcome := j.run(ctx, j)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.outcome = outcome
+	j.state = StateCompleted
+	if j.ctx.Err() != nil {
+		j.state = StateCancelled
+	}
+	j.doneAt = time.Now()
+}
+
+// logDone encerra o job no WAL, exceto quando foi interrompido pelo
+// desligamento: nesse caso ele é recuperado no próximo start
+func (j *Job) logDone() {
+	if j.wal == nil || errors.Is(context.Cause(j.ctx), ErrShutdown) {
+		return
+	}
+	j.wal.JobDone(j.id)
+}
+
+func (j *Job) finished() bool {
+	_, ok := j.finishedAt()
+	return ok
+}
+
+func (j *Job) finishedAt() (time.Time, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.doneAt, !j.doneAt.IsZero()
+}
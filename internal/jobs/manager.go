@@ -0,0 +1,243 @@
+// Package jobs executa operações em massa em background (?async=true). Cada
+// job entra em uma fila limitada e é processado por um pool de workers; o
+// progresso e os resultados por item ficam disponíveis em /pipedrive/jobs/{id}.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"pipedrive_api_service/internal/upstream"
+)
+
+const (
+	DefaultWorkers   = 2
+	DefaultQueueSize = 64
+	// DefaultTTL é por quanto tempo um job finalizado continua consultável
+	DefaultTTL = time.Hour
+)
+
+var (
+	ErrQueueFull = errors.New("job queue is full, try again later")
+	ErrNotFound  = errors.New("job not found")
+	ErrFinished  = errors.New("job already finished")
+	// ErrShutdown é a causa do cancelamento dos jobs em Stop
+	ErrShutdown = errors.New("service shutting down")
+)
+
+// RunFunc executa o job e retorna o resultado geral (e.g. "partial_failure").
+// Deve registrar cada item com Job.Record e parar quando ctx for cancelado.
+type RunFunc func(ctx context.Context, job *Job) string
+
+type Manager struct {
+	mu    sync.Mutex
+	jobs  map[string]*Job
+	queue chan *Job
+	ttl   time.Duration
+
+	workers int
+	quit    chan struct{}
+	wg      sync.WaitGroup
+
+	// wal registra os jobs e seus itens para a recuperação após um restart
+	wal *upstream.WAL
+}
+
+// NewManager cria o gerenciador com 'workers' jobs simultâneos e uma fila de
+// até 'queueSize' jobs aguardando.
+func NewManager(workers, queueSize int, ttl time.Duration) *Manager {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	m := &Manager{
+		jobs:    make(map[string]*Job),
+		queue:   make(chan *Job, queueSize),
+		ttl:     ttl,
+		workers: workers,
+		quit:    make(chan struct{}),
+	}
+	m.start()
+	return m
+}
+
+func (m *Manager) start() {
+	for i := 0; i < m.workers; i++ {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			for {
+				select {
+				case <-m.quit:
+					return
+				case job := <-m.queue:
+					job.execute()
+				}
+			}
+		}()
+	}
+}
+
+// SetWAL registra os jobs aceitos a partir daqui no WAL do broker: itens que
+// não terminarem antes de um restart são reportados por Recover.
+func (m *Manager) SetWAL(w *upstream.WAL) {
+	m.mu.Lock()
+	m.wal = w
+	m.mu.Unlock()
+}
+
+func (m *Manager) currentWAL() *upstream.WAL {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.wal
+}
+
+// Stop cancela os jobs pendentes e em andamento e aguarda os workers. Com
+// WAL, os jobs interrompidos continuam registrados para a recuperação.
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	for _, job := range m.jobs {
+		job.cancel(ErrShutdown)
+	}
+	m.mu.Unlock()
+
+	close(m.quit)
+	m.wg.Wait()
+}
+
+// Submit registra e enfileira um job com os itens 'items' (as chaves dos
+// resultados). origin.Operation nomeia o job; a origem inteira (e.g. a
+// Idempotency-Key) acompanha as escritas do job.
+func (m *Manager) Submit(origin upstream.TaskOrigin, items []string, run RunFunc) (*Job, error) {
+	origin.JobID = newID()
+	wal := m.currentWAL()
+	if wal != nil {
+		wal.JobQueued(origin, items)
+	}
+	job, err := m.submit(origin, len(items), run, false, wal)
+	if err != nil && wal != nil {
+		wal.JobDone(origin.JobID)
+	}
+	return job, err
+}
+
+// submit enfileira o job origin.JobID; com 'wal', cada item e o fim do job
+// são registrados no log
+func (m *Manager) submit(origin upstream.TaskOrigin, total int, run RunFunc, recovered bool, wal *upstream.WAL) (*Job, error) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	job := &Job{
+		id:        origin.JobID,
+		recovered: recovered,
+		operation: origin.Operation,
+		origin:    origin,
+		state:     StateQueued,
+		progress:  Progress{Total: total},
+		results:   make(map[string]interface{}, total),
+		createdAt: time.Now(),
+		ctx:       ctx,
+		cancel:    cancel,
+		run:       run,
+		wal:       wal,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purgeLocked(time.Now())
+
+	select {
+	case m.queue <- job:
+	default:
+		cancel(nil)
+		return nil, ErrQueueFull
+	}
+	m.jobs[job.id] = job
+	return job, nil
+}
+
+// Get retorna o estado atual do job.
+func (m *Manager) Get(id string) (Status, error) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	m.mu.Unlock()
+	if !ok {
+		return Status{}, ErrNotFound
+	}
+	return job.Status(true), nil
+}
+
+// List retorna o estado dos jobs conhecidos, do mais recente ao mais antigo,
+// sem os resultados por item.
+func (m *Manager) List() []Status {
+	m.mu.Lock()
+	m.purgeLocked(time.Now())
+	list := make([]Status, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		list = append(list, job.Status(false))
+	}
+	m.mu.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+	return list
+}
+
+// Cancel interrompe o job: itens ainda não iniciados não são executados.
+func (m *Manager) Cancel(id string) (Status, error) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	m.mu.Unlock()
+	if !ok {
+		return Status{}, ErrNotFound
+	}
+	if job.finished() {
+		return job.Status(false), ErrFinished
+	}
+	job.cancel(nil)
+	return job.Status(false), nil
+}
+
+func (m *Manager) purgeLocked(now time.Time) {
+	for id, job := range m.jobs {
+		if finishedAt, ok := job.finishedAt(); ok && now.Sub(finishedAt) > m.ttl {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+func newID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return "job_" + hex.EncodeToString(b)
+}
+
+// --- global singleton ---
+
+var (
+	globalMu sync.Mutex
+	global   *Manager
+)
+
+// SetGlobalManager registra o gerenciador usado pelas rotas com ?async=true.
+func SetGlobalManager(m *Manager) {
+	globalMu.Lock()
+	global = m
+	globalMu.Unlock()
+}
+
+// GlobalManager retorna o gerenciador global; nil quando não configurado.
+func GlobalManager() *Manager {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	return global
+}
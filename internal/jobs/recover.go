@@ -0,0 +1,256 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/idempotency"
+	"pipedrive_api_service/internal/upstream"
+	"pipedrive_api_service/internal/utils"
+)
+
+// recoveredOperation nomeia o job das escritas sem job nem Idempotency-Key
+const recoveredOperation = "recovered upstream writes"
+
+// DefaultReplayMaxAge é a idade máxima de uma escrita reenviada após um
+// restart; escritas mais antigas são reportadas como falha
+const DefaultReplayMaxAge = time.Hour
+
+// recoveryGroup reúne as escritas pendentes de uma mesma origem
+type recoveryGroup struct {
+	origin upstream.TaskOrigin
+	tasks  []upstream.PendingTask
+	// job assíncrono registrado no WAL (nil para escritas de requisições)
+	job *upstream.PendingJob
+	// mixed reúne escritas de requisições diferentes
+	mixed bool
+}
+
+// Recover transforma o que o WAL guardou de uma execução anterior em jobs
+// (marcados com 'recovered'), consultáveis em /pipedrive/jobs/{id}:
+//   - um job assíncrono volta com o ID e o total de itens originais; os itens
+//     que terminaram antes do restart mantêm o resultado (sucesso ou falha),
+//     as escritas pendentes são reenviadas e os demais itens são reportados
+//     como interrompidos;
+//   - escritas de uma requisição com Idempotency-Key viram um job, e a chave
+//     passa a responder com esse job (uma nova tentativa do cliente não
+//     repete a operação);
+//   - as demais escritas são reunidas em um único job.
+//
+// Um POST que pode ter chegado ao Pipedrive antes do restart não é repetido
+// (o Pipedrive criaria um registro duplicado), nem escritas mais antigas que
+// 'maxAge' (sobrescreveriam alterações mais novas); PUT, PATCH e DELETE
+// recentes são reenviados. Retorna os IDs dos jobs criados.
+func (m *Manager) Recover(backlog upstream.Backlog, store *idempotency.Store, maxAge time.Duration) []string {
+	if maxAge <= 0 {
+		maxAge = DefaultReplayMaxAge
+	}
+
+	groups := []*recoveryGroup{}
+	byKey := map[string]*recoveryGroup{}
+	for i := range backlog.Jobs {
+		pj := &backlog.Jobs[i]
+		g := &recoveryGroup{origin: pj.Origin, job: pj}
+		byKey["job:"+pj.ID] = g
+		groups = append(groups, g)
+	}
+	for _, task := range backlog.Tasks {
+		key := ""
+		switch {
+		case task.Origin.JobID != "":
+			key = "job:" + task.Origin.JobID
+		case task.Origin.IdempotencyKey != "":
+			key = "key:" + task.Origin.IdempotencyKey
+		}
+		g, ok := byKey[key]
+		if !ok {
+			g = &recoveryGroup{origin: task.Origin, mixed: key == ""}
+			if g.mixed {
+				g.origin = upstream.TaskOrigin{Operation: recoveredOperation}
+			}
+			if g.origin.JobID == "" {
+				g.origin.JobID = newID()
+			}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.tasks = append(g.tasks, task)
+	}
+
+	wal := m.currentWAL()
+	ids := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g.origin.Operation == "" {
+			g.origin.Operation = recoveredOperation
+		}
+		total := len(g.tasks)
+		var jobWAL *upstream.WAL
+		if g.job != nil {
+			total = len(g.job.Items)
+			jobWAL = wal
+		}
+		job, err := m.submit(g.origin, total, g.replay(maxAge), true, jobWAL)
+		if err != nil {
+			log.Printf("jobs: could not recover %s (%d pending writes): %v", g.origin.Operation, len(g.tasks), err)
+			continue
+		}
+		ids = append(ids, job.ID())
+
+		if store != nil && g.origin.IdempotencyKey != "" {
+			reserveKey(store, g.origin, job)
+		}
+	}
+	return ids
+}
+
+// reserveKey faz a Idempotency-Key original responder com o job recuperado
+func reserveKey(store *idempotency.Store, origin upstream.TaskOrigin, job *Job) {
+	if _, err := store.Begin(origin.IdempotencyKey, origin.Fingerprint); err != nil {
+		return
+	}
+	body, _ := json.Marshal(utils.Envelope{
+		Success: true,
+		Data:    job.Status(false),
+		Metadata: []utils.MetaItem{{
+			URL:    "(recovered job " + job.ID() + ")",
+			Status: http.StatusAccepted,
+		}},
+	})
+	store.Complete(origin.IdempotencyKey, &idempotency.Response{
+		Status: http.StatusAccepted,
+		Header: http.Header{
+			utils.HeaderContentType: {utils.ContentTypeJSON},
+			utils.HeaderLocation:    {"/pipedrive/jobs/" + job.ID()},
+		},
+		Body: append(body, '\n'),
+	})
+}
+
+// replay reenvia as escritas em sequência, na ordem original. Os resultados
+// são indexados pelo item de origem (índice ou ID); no job que reúne
+// requisições diferentes, pelo ID da escrita.
+func (g *recoveryGroup) replay(maxAge time.Duration) RunFunc {
+	mixed := g.mixed
+	return func(ctx context.Context, job *Job) string {
+		total := len(g.tasks)
+		pendingItems := map[string]bool{}
+		if g.job != nil {
+			total = len(g.job.Items)
+			for _, task := range g.tasks {
+				pendingItems[task.Origin.ItemKey] = true
+			}
+			for _, item := range g.job.Items {
+				if ok, finished := g.job.Finished[item]; finished {
+					job.Record(item, finishedResult(ok), ok)
+				}
+			}
+		}
+
+		for _, task := range g.tasks {
+			key := task.Origin.ItemKey
+			if key == "" || mixed {
+				key = task.ID
+			}
+			if ctx.Err() != nil {
+				// cancelado pelo usuário, a escrita não é mais reenviada; no
+				// desligamento, continua no WAL para o próximo start
+				if !errors.Is(context.Cause(ctx), ErrShutdown) {
+					upstream.GlobalBroker().Discard(task.ID)
+				}
+				continue
+			}
+			result, ok := replayTask(ctx, task, mixed, maxAge)
+			job.Record(key, result, ok)
+		}
+
+		// itens do job que não terminaram nem tinham escrita pendente
+		if g.job != nil && ctx.Err() == nil {
+			for _, item := range g.job.Items {
+				if _, finished := g.job.Finished[item]; finished || pendingItems[item] {
+					continue
+				}
+				job.Record(item, map[string]interface{}{
+					"error":       "interrupted by a service restart before the item finished; check the record before submitting it again",
+					"status":      http.StatusServiceUnavailable,
+					"interrupted": true,
+				}, false)
+			}
+		}
+
+		success := job.Status(false).Progress.Succeeded
+		switch {
+		case success == total:
+			return "success"
+		case success == 0:
+			return "failure"
+		}
+		return "partial_failure"
+	}
+}
+
+// finishedResult é o resultado de um item que terminou antes do restart; o
+// resultado original não é guardado no WAL
+func finishedResult(ok bool) map[string]interface{} {
+	if ok {
+		return map[string]interface{}{"note": "finished before the service restart; the original result was not kept"}
+	}
+	return map[string]interface{}{"error": "failed before the service restart; the original error was not kept"}
+}
+
+// replayTask reenvia uma escrita; retorna o resultado do item e se ele teve
+// sucesso
+func replayTask(ctx context.Context, task upstream.PendingTask, mixed bool, maxAge time.Duration) (map[string]interface{}, bool) {
+	broker := upstream.GlobalBroker()
+	result := map[string]interface{}{
+		"method": task.Method,
+		"path":   taskPath(task.URL),
+	}
+	if mixed {
+		result["operation"] = task.Origin.Operation
+		result["item"] = task.Origin.ItemKey
+	}
+
+	if task.Sent && task.Method == http.MethodPost {
+		broker.Discard(task.ID)
+		result["error"] = "request may have reached Pipedrive before the restart; not replayed to avoid a duplicate"
+		result["status"] = http.StatusConflict
+		return result, false
+	}
+	if age := time.Since(task.CreatedAt); age > maxAge {
+		broker.Discard(task.ID)
+		result["error"] = fmt.Sprintf("queued %s before the replay, more than the limit of %s; not replayed to avoid overwriting newer changes", age.Round(time.Second), maxAge)
+		result["status"] = http.StatusConflict
+		return result, false
+	}
+
+	resp, body, _, err := client.NewPipedriveClient().Replay(ctx, task)
+	if err != nil {
+		result["error"] = fmt.Sprintf("replay failed: %v", err)
+		result["status"] = http.StatusServiceUnavailable
+		return result, false
+	}
+	result["status"] = resp.StatusCode
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+	if resp.StatusCode >= 400 {
+		result["error"] = parsed["error"]
+		return result, false
+	}
+	result["data"] = parsed["data"]
+	return result, true
+}
+
+func taskPath(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return u.Path
+}
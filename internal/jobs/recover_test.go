@@ -0,0 +1,276 @@
+package jobs
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"pipedrive_api_service/internal/idempotency"
+	"pipedrive_api_service/internal/upstream"
+	"pipedrive_api_service/internal/utils"
+)
+
+// fakePipedrive registra as escritas recebidas e responde 200
+type fakePipedrive struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakePipedrive) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+	f.mu.Unlock()
+	w.Header().Set(utils.HeaderContentType, utils.ContentTypeJSON)
+	_, _ = w.Write([]byte(`{"success":true,"data":{"id":1}}`))
+}
+
+func (f *fakePipedrive) received() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// setupRecovery inicia um Pipedrive falso, o broker global com WAL e um
+// gerenciador de jobs
+func setupRecovery(t *testing.T) (*fakePipedrive, *Manager, string) {
+	t.Helper()
+	fake := &fakePipedrive{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	t.Setenv("PIPEDRIVE_BASE_URL", server.URL)
+	t.Setenv("PIPEDRIVE_API_TOKEN", "token")
+
+	wal, _, err := upstream.OpenWAL(filepath.Join(t.TempDir(), "wal.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker := upstream.NewUpstreamBroker(1, 16)
+	broker.SetWAL(wal)
+	upstream.SetGlobalBroker(broker)
+
+	m := NewManager(1, 16, time.Hour)
+	m.SetWAL(wal)
+	t.Cleanup(func() {
+		m.Stop()
+		broker.Stop()
+		upstream.SetGlobalBroker(nil)
+		wal.Close()
+	})
+	return fake, m, server.URL
+}
+
+func waitJob(t *testing.T, m *Manager, id string) Status {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		status, err := m.Get(id)
+		if err != nil {
+			t.Fatalf("job %s: %v", id, err)
+		}
+		if status.FinishedAt != nil {
+			return status
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return Status{}
+}
+
+func pendingTask(id, method, url string, origin upstream.TaskOrigin) upstream.PendingTask {
+	return upstream.PendingTask{
+		ID:        id,
+		Method:    method,
+		URL:       url,
+		Body:      []byte(`{}`),
+		Origin:    origin,
+		CreatedAt: time.Now(),
+	}
+}
+
+func resultStatus(t *testing.T, s Status, key string) int {
+	t.Helper()
+	result, ok := s.Results[key].(map[string]interface{})
+	if !ok {
+		t.Fatalf("no result for %s in %+v", key, s.Results)
+	}
+	status, _ := result["status"].(int)
+	return status
+}
+
+func TestRecoverGroupsWrites(t *testing.T) {
+	fake, m, base := setupRecovery(t)
+
+	backlog := upstream.Backlog{Tasks: []upstream.PendingTask{
+		pendingTask("t1", http.MethodPut, base+"/deals/1", upstream.TaskOrigin{JobID: "job_a", Operation: "PUT /pipedrive/deals", ItemKey: "1"}),
+		pendingTask("t2", http.MethodPut, base+"/deals/2", upstream.TaskOrigin{JobID: "job_a", Operation: "PUT /pipedrive/deals", ItemKey: "2"}),
+		pendingTask("t3", http.MethodDelete, base+"/deals/3", upstream.TaskOrigin{Operation: "DELETE /pipedrive/deals", IdempotencyKey: "k1", Fingerprint: "fp", ItemKey: "3"}),
+		pendingTask("t4", http.MethodPut, base+"/organizations/4", upstream.TaskOrigin{Operation: "PUT /pipedrive/organizations/4"}),
+		pendingTask("t5", http.MethodDelete, base+"/persons/5", upstream.TaskOrigin{}),
+	}}
+
+	ids := m.Recover(backlog, nil, time.Hour)
+	if len(ids) != 3 || ids[0] != "job_a" {
+		t.Fatalf("recovered jobs = %v; want job_a, the idempotent request and the anonymous group", ids)
+	}
+
+	jobA := waitJob(t, m, "job_a")
+	if !jobA.Recovered || jobA.Operation != "PUT /pipedrive/deals" || jobA.Progress.Total != 2 || jobA.Outcome != "success" {
+		t.Fatalf("job_a = %+v", jobA)
+	}
+	if resultStatus(t, jobA, "1") != http.StatusOK || resultStatus(t, jobA, "2") != http.StatusOK {
+		t.Fatalf("job_a results = %+v", jobA.Results)
+	}
+
+	keyed := waitJob(t, m, ids[1])
+	if keyed.Operation != "DELETE /pipedrive/deals" || keyed.Progress.Succeeded != 1 {
+		t.Fatalf("idempotent job = %+v", keyed)
+	}
+
+	mixed := waitJob(t, m, ids[2])
+	if mixed.Operation != recoveredOperation || mixed.Progress.Total != 2 {
+		t.Fatalf("anonymous job = %+v", mixed)
+	}
+	// no job misto, os resultados são indexados pelo ID da escrita
+	if resultStatus(t, mixed, "t4") != http.StatusOK || resultStatus(t, mixed, "t5") != http.StatusOK {
+		t.Fatalf("anonymous job results = %+v", mixed.Results)
+	}
+
+	calls := fake.received()
+	sort.Strings(calls)
+	want := []string{"DELETE /deals/3", "DELETE /persons/5", "PUT /deals/1", "PUT /deals/2", "PUT /organizations/4"}
+	if len(calls) != len(want) {
+		t.Fatalf("replayed %v; want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("replayed %v; want %v", calls, want)
+		}
+	}
+}
+
+func TestRecoverDoesNotReplaySentPOST(t *testing.T) {
+	fake, m, base := setupRecovery(t)
+
+	sent := pendingTask("t1", http.MethodPost, base+"/deals", upstream.TaskOrigin{JobID: "job_a", ItemKey: "0"})
+	sent.Sent = true
+	notSent := pendingTask("t2", http.MethodPost, base+"/deals", upstream.TaskOrigin{JobID: "job_a", ItemKey: "1"})
+
+	m.Recover(upstream.Backlog{Tasks: []upstream.PendingTask{sent, notSent}}, nil, time.Hour)
+	status := waitJob(t, m, "job_a")
+
+	if resultStatus(t, status, "0") != http.StatusConflict {
+		t.Fatalf("sent POST result = %+v; want 409", status.Results["0"])
+	}
+	if resultStatus(t, status, "1") != http.StatusOK {
+		t.Fatalf("unsent POST result = %+v; want 200", status.Results["1"])
+	}
+	if calls := fake.received(); len(calls) != 1 {
+		t.Fatalf("replayed %v; want only the unsent POST", calls)
+	}
+	if status.Outcome != "partial_failure" {
+		t.Fatalf("outcome = %s; want partial_failure", status.Outcome)
+	}
+}
+
+func TestRecoverSkipsOldWrites(t *testing.T) {
+	fake, m, base := setupRecovery(t)
+
+	old := pendingTask("t1", http.MethodPut, base+"/deals/1", upstream.TaskOrigin{JobID: "job_a", ItemKey: "1"})
+	old.CreatedAt = time.Now().Add(-2 * time.Hour)
+
+	m.Recover(upstream.Backlog{Tasks: []upstream.PendingTask{old}}, nil, time.Hour)
+	status := waitJob(t, m, "job_a")
+
+	if resultStatus(t, status, "1") != http.StatusConflict || status.Outcome != "failure" {
+		t.Fatalf("job = %+v; want the old write reported as failed", status)
+	}
+	if calls := fake.received(); len(calls) != 0 {
+		t.Fatalf("replayed %v; want nothing", calls)
+	}
+}
+
+func TestRecoverReportsInterruptedItems(t *testing.T) {
+	fake, m, base := setupRecovery(t)
+
+	backlog := upstream.Backlog{
+		Jobs: []upstream.PendingJob{{
+			ID:       "job_a",
+			Origin:   upstream.TaskOrigin{JobID: "job_a", Operation: "PUT /pipedrive/deals"},
+			Items:    []string{"1", "2", "3", "4"},
+			Finished: map[string]bool{"1": true, "2": false},
+		}},
+		Tasks: []upstream.PendingTask{
+			pendingTask("t3", http.MethodPut, base+"/deals/3", upstream.TaskOrigin{JobID: "job_a", ItemKey: "3"}),
+		},
+	}
+
+	ids := m.Recover(backlog, nil, time.Hour)
+	if len(ids) != 1 || ids[0] != "job_a" {
+		t.Fatalf("recovered jobs = %v; want [job_a]", ids)
+	}
+	status := waitJob(t, m, "job_a")
+
+	want := Progress{Total: 4, Processed: 4, Succeeded: 2, Failed: 2}
+	if status.Progress != want {
+		t.Fatalf("progress = %+v; want %+v", status.Progress, want)
+	}
+	if status.Outcome != "partial_failure" {
+		t.Fatalf("outcome = %s; want partial_failure", status.Outcome)
+	}
+	interrupted, _ := status.Results["4"].(map[string]interface{})
+	if interrupted["interrupted"] != true || interrupted["status"] != http.StatusServiceUnavailable {
+		t.Fatalf("item 4 = %+v; want it marked as interrupted", status.Results["4"])
+	}
+	if calls := fake.received(); len(calls) != 1 || calls[0] != "PUT /deals/3" {
+		t.Fatalf("replayed %v; want only the pending write", calls)
+	}
+}
+
+func TestRecoverJobWithoutPendingWrites(t *testing.T) {
+	_, m, _ := setupRecovery(t)
+
+	// job que ainda estava na fila: nenhum item chegou ao broker
+	backlog := upstream.Backlog{Jobs: []upstream.PendingJob{{
+		ID:       "job_a",
+		Origin:   upstream.TaskOrigin{JobID: "job_a", Operation: "DELETE /pipedrive/deals"},
+		Items:    []string{"1", "2"},
+		Finished: map[string]bool{},
+	}}}
+
+	m.Recover(backlog, nil, time.Hour)
+	status := waitJob(t, m, "job_a")
+
+	if status.Progress.Total != 2 || status.Progress.Failed != 2 || status.Outcome != "failure" {
+		t.Fatalf("job = %+v; want both items interrupted", status)
+	}
+}
+
+func TestRecoverReservesIdempotencyKey(t *testing.T) {
+	_, m, base := setupRecovery(t)
+	store := idempotency.NewStore(time.Hour)
+
+	origin := upstream.TaskOrigin{Operation: "DELETE /pipedrive/deals", IdempotencyKey: "k1", Fingerprint: "fp", ItemKey: "3"}
+	ids := m.Recover(upstream.Backlog{Tasks: []upstream.PendingTask{
+		pendingTask("t1", http.MethodDelete, base+"/deals/3", origin),
+	}}, store, time.Hour)
+	if len(ids) != 1 {
+		t.Fatalf("recovered jobs = %v", ids)
+	}
+
+	stored, err := store.Begin("k1", "fp")
+	if err != nil || stored == nil {
+		t.Fatalf("Begin = %v, %v; want the recovered job response", stored, err)
+	}
+	if stored.Status != http.StatusAccepted || stored.Header.Get(utils.HeaderLocation) != "/pipedrive/jobs/"+ids[0] {
+		t.Fatalf("stored response = %d %v", stored.Status, stored.Header)
+	}
+	if _, err := store.Begin("k1", "other"); !errors.Is(err, idempotency.ErrMismatch) {
+		t.Fatalf("Begin with another request = %v; want ErrMismatch", err)
+	}
+	waitJob(t, m, ids[0])
+}
@@ -0,0 +1,32 @@
+package models
+
+// Activity representa uma atividade (ligação, reunião, tarefa) do Pipedrive em modo de listagem.
+type Activity struct {
+	ID               int    `json:"id"`
+	CompanyID        int    `json:"company_id"`
+	UserID           int    `json:"user_id"`
+	Done             bool   `json:"done"`
+	Type             string `json:"type"`
+	Subject          string `json:"subject"`
+	DueDate          string `json:"due_date"`
+	DueTime          string `json:"due_time"`
+	Duration         string `json:"duration"`
+	DealID           int    `json:"deal_id"`
+	PersonID         int    `json:"person_id"`
+	OrgID            int    `json:"org_id"`
+	LeadID           string `json:"lead_id"`
+	Note             string `json:"note"`
+	Location         string `json:"location"`
+	BusyFlag         bool   `json:"busy_flag"`
+	PersonName       string `json:"person_name"`
+	OrgName          string `json:"org_name"`
+	DealTitle        string `json:"deal_title"`
+	OwnerName        string `json:"owner_name"`
+	AddTime          string `json:"add_time"`
+	UpdateTime       string `json:"update_time"`
+	MarkedAsDoneTime string `json:"marked_as_done_time"`
+	ActiveFlag       bool   `json:"active_flag"`
+}
+
+// ActivitiesResponse é o envelope retornado no GET /activities (modo de listagem)
+type ActivitiesResponse = ListResponse[Activity]
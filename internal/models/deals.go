@@ -35,27 +35,7 @@ type Deal struct {
 }
 
 // DealsResponse é o envelope retornado no GET /deals (modo de listagem)
-type DealsResponse struct {
-	Success        bool        `json:"success"`
-	Data           []Deal      `json:"data"`
-	Error          interface{} `json:"error"`
-	AdditionalData struct {
-		Pagination struct {
-			MoreItemsInCollection bool `json:"more_items_in_collection"`
-			NextStart             int  `json:"next_start"`
-		} `json:"pagination"`
-	} `json:"additional_data"`
-}
-
-func (r *DealsResponse) GetDataSlice() interface{} {
-	return r.Data
-}
-
-func (r *DealsResponse) SetDataSlice(data interface{}) {
-	if filteredData, ok := data.([]Deal); ok {
-		r.Data = filteredData
-	}
-}
+type DealsResponse = ListResponse[Deal]
 
 // DealUpdateData representa o corpo da requisição PUT/PATCH para atualização em massa
 type DealUpdateData map[string]map[string]interface{}
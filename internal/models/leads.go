@@ -0,0 +1,30 @@
+package models
+
+// LeadValue representa o valor potencial de um lead
+type LeadValue struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// Lead representa um lead do Pipedrive. Diferente de deals e organizações,
+// o ID de um lead é um UUID (string).
+type Lead struct {
+	ID                string     `json:"id"`
+	Title             string     `json:"title"`
+	OwnerID           int        `json:"owner_id"`
+	CreatorID         int        `json:"creator_id"`
+	PersonID          int        `json:"person_id"`
+	OrganizationID    int        `json:"organization_id"`
+	SourceName        string     `json:"source_name"`
+	IsArchived        bool       `json:"is_archived"`
+	WasSeen           bool       `json:"was_seen"`
+	Value             *LeadValue `json:"value"`
+	ExpectedCloseDate string     `json:"expected_close_date"`
+	LabelIDs          []string   `json:"label_ids"`
+	VisibleTo         string     `json:"visible_to"`
+	AddTime           string     `json:"add_time"`
+	UpdateTime        string     `json:"update_time"`
+}
+
+// LeadsResponse é o envelope retornado no GET /leads (modo de listagem)
+type LeadsResponse = ListResponse[Lead]
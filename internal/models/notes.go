@@ -0,0 +1,23 @@
+package models
+
+// Note representa uma anotação do Pipedrive vinculada a deal, pessoa, organização ou lead.
+// O campo 'content' é HTML.
+type Note struct {
+	ID                       int    `json:"id"`
+	UserID                   int    `json:"user_id"`
+	DealID                   int    `json:"deal_id"`
+	PersonID                 int    `json:"person_id"`
+	OrgID                    int    `json:"org_id"`
+	LeadID                   string `json:"lead_id"`
+	Content                  string `json:"content"`
+	PinnedToDealFlag         bool   `json:"pinned_to_deal_flag"`
+	PinnedToPersonFlag       bool   `json:"pinned_to_person_flag"`
+	PinnedToOrganizationFlag bool   `json:"pinned_to_organization_flag"`
+	PinnedToLeadFlag         bool   `json:"pinned_to_lead_flag"`
+	AddTime                  string `json:"add_time"`
+	UpdateTime               string `json:"update_time"`
+	ActiveFlag               bool   `json:"active_flag"`
+}
+
+// NotesResponse é o envelope retornado no GET /notes (modo de listagem)
+type NotesResponse = ListResponse[Note]
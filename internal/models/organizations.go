@@ -23,27 +23,7 @@ type Organization struct {
 }
 
 // OrganizationsResponse é a estrutura de envelope para a listagem (GET sem ID)
-type OrganizationsResponse struct {
-	Success        bool           `json:"success"`
-	Data           []Organization `json:"data"` // Slice da struct esparsa Organization
-	Error          interface{}    `json:"error"`
-	AdditionalData struct {
-		Pagination struct {
-			MoreItemsInCollection bool `json:"more_items_in_collection"`
-			NextStart             int  `json:"next_start"`
-		} `json:"pagination"`
-	} `json:"additional_data"`
-}
-
-func (r *OrganizationsResponse) GetDataSlice() interface{} {
-	return r.Data
-}
-
-func (r *OrganizationsResponse) SetDataSlice(data interface{}) {
-	if filteredData, ok := data.([]Organization); ok {
-		r.Data = filteredData
-	}
-}
+type OrganizationsResponse = ListResponse[Organization]
 
 // OrganizationUpdateData representa o corpo de uma requisição PUT/PATCH para atualização em massa
 type OrganizationUpdateData map[string]map[string]interface{}
@@ -51,5 +31,6 @@ type OrganizationUpdateData map[string]map[string]interface{}
 // BulkUpdateResult representa o item de retorno de uma operação de atualização em massa
 type BulkUpdateResult struct {
 	Status  string                 `json:"status"`
+	DryRun  bool                   `json:"dry_run,omitempty"`
 	Results map[string]interface{} `json:"results"`
 }
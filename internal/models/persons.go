@@ -0,0 +1,31 @@
+package models
+
+// ContactInfo representa um item das listas 'email' e 'phone' de uma pessoa
+type ContactInfo struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+	Label   string `json:"label,omitempty"`
+}
+
+// Person representa a estrutura base de uma pessoa (contato) em modo de listagem.
+// Os campos 'email' e 'phone' são listas; o filtro local compara cada 'value'.
+type Person struct {
+	ID           int              `json:"id"`
+	CompanyID    int              `json:"company_id"`
+	Name         string           `json:"name"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	Email        []ContactInfo    `json:"email"`
+	Phone        []ContactInfo    `json:"phone"`
+	Organization OrganizationInfo `json:"org_id"`
+	Owner        OwnerInfo        `json:"owner_id"`
+	AddTime      string           `json:"add_time"`
+	UpdateTime   string           `json:"update_time"`
+	ActiveFlag   bool             `json:"active_flag"`
+}
+
+// PersonsResponse é o envelope retornado no GET /persons (modo de listagem)
+type PersonsResponse = ListResponse[Person]
+
+// PersonUpdateData representa o corpo da requisição PUT/PATCH para atualização em massa
+type PersonUpdateData map[string]map[string]interface{}
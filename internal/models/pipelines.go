@@ -6,6 +6,8 @@ type Pipeline struct {
 	URLTitle string `json:"url_title"`
 	OrderNr  int    `json:"order_nr"`
 	Active   bool   `json:"active"`
+	// Stages é preenchido apenas com ?include=stages
+	Stages []Stage `json:"stages,omitempty"`
 }
 
 type PipelinesResponse struct {
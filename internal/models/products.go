@@ -0,0 +1,46 @@
+package models
+
+// ProductPrice representa um preço do produto em uma moeda
+type ProductPrice struct {
+	Currency     string  `json:"currency"`
+	Price        float64 `json:"price"`
+	Cost         float64 `json:"cost,omitempty"`
+	OverheadCost float64 `json:"overhead_cost,omitempty"`
+}
+
+// Product representa a estrutura base de um produto do catálogo em modo de listagem.
+type Product struct {
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`
+	Code        string         `json:"code"`
+	Description string         `json:"description"`
+	Unit        string         `json:"unit"`
+	Tax         float64        `json:"tax"`
+	ActiveFlag  bool           `json:"active_flag"`
+	Selectable  bool           `json:"selectable"`
+	VisibleTo   string         `json:"visible_to"`
+	Owner       OwnerInfo      `json:"owner_id"`
+	Prices      []ProductPrice `json:"prices"`
+	AddTime     string         `json:"add_time"`
+	UpdateTime  string         `json:"update_time"`
+}
+
+// ProductsResponse é o envelope retornado no GET /products (modo de listagem)
+type ProductsResponse = ListResponse[Product]
+
+// DealProduct representa um produto anexado a um deal (linha de item)
+type DealProduct struct {
+	ID           int     `json:"id"`
+	DealID       int     `json:"deal_id"`
+	ProductID    int     `json:"product_id"`
+	Name         string  `json:"name"`
+	ItemPrice    float64 `json:"item_price"`
+	Quantity     float64 `json:"quantity"`
+	Discount     float64 `json:"discount"`
+	DiscountType string  `json:"discount_type"`
+	Tax          float64 `json:"tax"`
+	Sum          float64 `json:"sum"`
+	Currency     string  `json:"currency"`
+	Comments     string  `json:"comments"`
+	EnabledFlag  bool    `json:"enabled_flag"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// ListResponse é o envelope paginado retornado pelas listagens do Pipedrive
+// (GET /deals, /organizations, /persons, ...).
+type ListResponse[T any] struct {
+	Success        bool        `json:"success"`
+	Data           []T         `json:"data"`
+	Error          interface{} `json:"error"`
+	AdditionalData struct {
+		Pagination struct {
+			MoreItemsInCollection bool `json:"more_items_in_collection"`
+			NextStart             int  `json:"next_start"`
+		} `json:"pagination"`
+	} `json:"additional_data"`
+}
+
+func (r *ListResponse[T]) GetDataSlice() interface{} {
+	return r.Data
+}
+
+func (r *ListResponse[T]) SetDataSlice(data interface{}) {
+	if filteredData, ok := data.([]T); ok {
+		r.Data = filteredData
+	}
+}
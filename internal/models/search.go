@@ -0,0 +1,25 @@
+package models
+
+// SearchResultItem representa um resultado do /itemSearch. O conteúdo de 'item'
+// varia conforme o tipo (deal, person, organization, product, lead).
+type SearchResultItem struct {
+	ResultScore float64                `json:"result_score"`
+	Item        map[string]interface{} `json:"item"`
+}
+
+// SearchResponse é o envelope retornado pelo GET /itemSearch
+type SearchResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Items []SearchResultItem `json:"items"`
+	} `json:"data"`
+	Error          interface{} `json:"error"`
+	AdditionalData struct {
+		Pagination struct {
+			Start                 int  `json:"start"`
+			Limit                 int  `json:"limit"`
+			MoreItemsInCollection bool `json:"more_items_in_collection"`
+			NextStart             int  `json:"next_start"`
+		} `json:"pagination"`
+	} `json:"additional_data"`
+}
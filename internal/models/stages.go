@@ -0,0 +1,32 @@
+package models
+
+// Stage representa uma etapa de um pipeline
+type Stage struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	OrderNr         int    `json:"order_nr"`
+	PipelineID      int    `json:"pipeline_id"`
+	PipelineName    string `json:"pipeline_name"`
+	DealProbability int    `json:"deal_probability"`
+	RottenFlag      bool   `json:"rotten_flag"`
+	RottenDays      int    `json:"rotten_days"`
+	ActiveFlag      bool   `json:"active_flag"`
+	AddTime         string `json:"add_time"`
+	UpdateTime      string `json:"update_time"`
+}
+
+type StagesResponse struct {
+	Success bool        `json:"success"`
+	Data    []Stage     `json:"data"`
+	Error   interface{} `json:"error"`
+}
+
+func (r *StagesResponse) GetDataSlice() interface{} {
+	return r.Data
+}
+
+func (r *StagesResponse) SetDataSlice(data interface{}) {
+	if filteredData, ok := data.([]Stage); ok {
+		r.Data = filteredData
+	}
+}
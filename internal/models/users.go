@@ -0,0 +1,34 @@
+package models
+
+// User representa um usuário da conta Pipedrive (possível dono de deals e organizações)
+type User struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	ActiveFlag      bool   `json:"active_flag"`
+	IsAdmin         int    `json:"is_admin"`
+	RoleID          int    `json:"role_id"`
+	TimezoneName    string `json:"timezone_name"`
+	DefaultCurrency string `json:"default_currency"`
+	Locale          string `json:"locale"`
+	IconURL         string `json:"icon_url"`
+	IsYou           bool   `json:"is_you"`
+	Created         string `json:"created"`
+	Modified        string `json:"modified"`
+}
+
+type UsersResponse struct {
+	Success bool        `json:"success"`
+	Data    []User      `json:"data"`
+	Error   interface{} `json:"error"`
+}
+
+func (r *UsersResponse) GetDataSlice() interface{} {
+	return r.Data
+}
+
+func (r *UsersResponse) SetDataSlice(data interface{}) {
+	if filteredData, ok := data.([]User); ok {
+		r.Data = filteredData
+	}
+}
@@ -0,0 +1,253 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"pipedrive_api_service/internal/jobs"
+	"pipedrive_api_service/internal/upstream"
+	"pipedrive_api_service/internal/utils"
+)
+
+// Execução paralela dos itens de POST, PUT e DELETE em massa. As chamadas ao
+// Pipedrive continuam passando pelo broker; aqui só se limita quantos itens de
+// uma requisição ficam em andamento ao mesmo tempo.
+
+// DefaultBulkConcurrency é o número padrão de itens executados ao mesmo tempo
+const DefaultBulkConcurrency = 4
+
+// DefaultBulkDeadline é o prazo para iniciar itens de uma requisição. Somado
+// ao bulkItemTimeout dos itens já iniciados, fica abaixo do WriteTimeout do
+// servidor (30s).
+const DefaultBulkDeadline = 20 * time.Second
+
+// bulkItemTimeout limita cada item iniciado, independente do prazo da
+// requisição
+const bulkItemTimeout = DefaultTimeout
+
+var (
+	bulkSettingsMu sync.RWMutex
+	bulkMaxWorkers = DefaultBulkConcurrency
+	bulkDeadline   = DefaultBulkDeadline
+)
+
+// SetBulkConcurrency define o limite de itens simultâneos; ?concurrency= só
+// pode reduzi-lo
+func SetBulkConcurrency(n int) {
+	if n <= 0 {
+		n = DefaultBulkConcurrency
+	}
+	bulkSettingsMu.Lock()
+	bulkMaxWorkers = n
+	bulkSettingsMu.Unlock()
+}
+
+// SetBulkDeadline define o prazo para iniciar itens de uma requisição em massa
+func SetBulkDeadline(d time.Duration) {
+	if d <= 0 {
+		d = DefaultBulkDeadline
+	}
+	bulkSettingsMu.Lock()
+	bulkDeadline = d
+	bulkSettingsMu.Unlock()
+}
+
+func currentBulkSettings() (int, time.Duration) {
+	bulkSettingsMu.RLock()
+	defer bulkSettingsMu.RUnlock()
+	return bulkMaxWorkers, bulkDeadline
+}
+
+// bulkConcurrency lê ?concurrency=, limitado a SetBulkConcurrency
+func bulkConcurrency(r *http.Request) (int, error) {
+	limit, _ := currentBulkSettings()
+	raw := strings.TrimSpace(r.URL.Query().Get("concurrency"))
+	if raw == "" {
+		return limit, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid concurrency '%s': use a positive integer", raw)
+	}
+	if n > limit {
+		n = limit
+	}
+	return n, nil
+}
+
+// bulkRun acumula os resultados de itens executados em paralelo
+type bulkRun struct {
+	mu      sync.Mutex
+	results map[string]interface{}
+	success int
+
+	// deadline limita a execução; zero em jobs assíncronos
+	deadline time.Duration
+	// job recebe cada resultado quando a execução é assíncrona
+	job *jobs.Job
+}
+
+func newBulkRun(size int) *bulkRun {
+	_, deadline := currentBulkSettings()
+	return &bulkRun{
+		results:  make(map[string]interface{}, size),
+		deadline: deadline,
+	}
+}
+
+// set grava o resultado de um item; ok conta como sucesso
+func (b *bulkRun) set(key string, result interface{}, ok bool) {
+	b.mu.Lock()
+	b.results[key] = result
+	if ok {
+		b.success++
+	}
+	b.mu.Unlock()
+
+	if b.job != nil {
+		b.job.Record(key, result, ok)
+	}
+}
+
+func (b *bulkRun) fail(key string, status int, err error) {
+	b.set(key, map[string]interface{}{
+		"error":  err.Error(),
+		"status": status,
+	}, false)
+}
+
+// run executa fn para cada item, até 'limit' ao mesmo tempo. Itens com a mesma
+// 'lane' (e.g. o mesmo ID) rodam em sequência, na ordem do corpo. O prazo de
+// SetBulkDeadline e o cancelamento de 'ctx' só impedem o início de novos
+// itens: os que não começaram a tempo são reportados com 504 em 'keys[i]';
+// após um cancelamento, não são executados.
+//
+// Um item iniciado roda até o fim sob bulkItemTimeout. Interrompê-lo no meio
+// não desfaz a escrita já enfileirada no broker, que seria enviada ao
+// Pipedrive enquanto o item é reportado como falha.
+func (b *bulkRun) run(ctx context.Context, limit int, keys, lanes []string, fn func(ctx context.Context, i int)) {
+	if b.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.deadline)
+		defer cancel()
+	}
+
+	order := make([]string, 0, len(lanes))
+	queued := make(map[string][]int, len(lanes))
+	for i, lane := range lanes {
+		if _, ok := queued[lane]; !ok {
+			order = append(order, lane)
+		}
+		queued[lane] = append(queued[lane], i)
+	}
+
+	expired := func(i int) {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			b.fail(keys[i], http.StatusGatewayTimeout, fmt.Errorf("bulk deadline of %s exceeded before the item ran", b.deadline))
+		}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+	for _, lane := range order {
+		items := queued[lane]
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for _, i := range items {
+				expired(i)
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(items []int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			for _, i := range items {
+				if ctx.Err() != nil {
+					expired(i)
+					continue
+				}
+				itemCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), bulkItemTimeout)
+				fn(upstream.WithOrigin(itemCtx, upstream.TaskOrigin{ItemKey: keys[i]}), i)
+				cancel()
+			}
+		}(items)
+	}
+	wg.Wait()
+}
+
+// asyncRequested indica ?async=true
+func asyncRequested(r *http.Request) bool {
+	async, _ := strconv.ParseBool(r.URL.Query().Get("async"))
+	return async
+}
+
+// RejectAsync responde 400 a ?async=true em rotas em massa que não rodam como
+// job e retorna true nesse caso
+func RejectAsync(w http.ResponseWriter, r *http.Request) bool {
+	if !asyncRequested(r) {
+		return false
+	}
+	utils.JSONError(w, http.StatusBadRequest, "async=true is not supported on this route", nil)
+	return true
+}
+
+// indexKeys retorna as chaves "0".."n-1" dos itens indexados pela posição
+func indexKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}
+
+// runBulk executa 'exec' durante a requisição e retorna os resultados. Com
+// ?async=true, enfileira a execução como job, responde 202 com o job e
+// retorna nil. 'keys' são as chaves dos resultados, uma por item.
+func runBulk(w http.ResponseWriter, r *http.Request, start time.Time, url string, keys []string, exec func(ctx context.Context, bulk *bulkRun)) *bulkRun {
+	total := len(keys)
+	origin := upstream.TaskOrigin{Operation: r.Method + " " + r.URL.Path}
+	if !asyncRequested(r) {
+		bulk := newBulkRun(total)
+		exec(upstream.WithOrigin(r.Context(), origin), bulk)
+		return bulk
+	}
+	// o job herda a chave de idempotência da requisição, se houver
+	origin = upstream.OriginFrom(upstream.WithOrigin(r.Context(), origin))
+
+	manager := jobs.GlobalManager()
+	if manager == nil {
+		utils.JSONError(w, http.StatusServiceUnavailable, "async jobs are not enabled", nil)
+		return nil
+	}
+
+	job, err := manager.Submit(origin, keys, func(ctx context.Context, job *jobs.Job) string {
+		bulk := newBulkRun(total)
+		bulk.deadline = 0
+		bulk.job = job
+		exec(ctx, bulk)
+		return BulkStatus(bulk.success, total)
+	})
+	if err != nil {
+		utils.JSONError(w, http.StatusServiceUnavailable, err.Error(), nil)
+		return nil
+	}
+
+	status := job.Status(false)
+	meta := utils.NewMetaItem(start, r.Header.Get(utils.HeaderXRequestID), url+" (async job)", http.StatusAccepted, nil)
+	w.Header().Set(utils.HeaderLocation, "/pipedrive/jobs/"+status.ID)
+	utils.JSON(w, http.StatusAccepted, utils.Envelope{
+		Success:  true,
+		Data:     status,
+		Metadata: []utils.MetaItem{*meta},
+	})
+	return nil
+}
@@ -0,0 +1,166 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testBulkRun(deadline time.Duration) *bulkRun {
+	return &bulkRun{results: map[string]interface{}{}, deadline: deadline}
+}
+
+func resultStatus(t *testing.T, b *bulkRun, key string) int {
+	t.Helper()
+	m, ok := b.results[key].(map[string]interface{})
+	if !ok {
+		t.Fatalf("result %s = %v; want an object", key, b.results[key])
+	}
+	status, _ := m["status"].(int)
+	return status
+}
+
+func TestBulkConcurrency(t *testing.T) {
+	SetBulkConcurrency(4)
+	t.Cleanup(func() { SetBulkConcurrency(DefaultBulkConcurrency) })
+
+	tests := []struct {
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{query: "", want: 4},
+		{query: "concurrency=2", want: 2},
+		{query: "concurrency=50", want: 4},
+		{query: "concurrency=0", wantErr: true},
+		{query: "concurrency=abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/pipedrive/deals?"+tt.query, nil)
+			got, err := bulkConcurrency(r)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %d", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("bulkConcurrency = %d, %v; want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkRunLanes(t *testing.T) {
+	keys := []string{"0", "1", "2", "3", "4", "5"}
+	lanes := []string{"10", "20", "10", "30", "10", "20"}
+
+	var mu sync.Mutex
+	order := map[string][]string{}
+	running, maxRunning := 0, 0
+
+	b := testBulkRun(0)
+	b.run(context.Background(), 2, keys, lanes, func(ctx context.Context, i int) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		order[lanes[i]] = append(order[lanes[i]], keys[i])
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		b.set(keys[i], "ok", true)
+	})
+
+	// itens da mesma lane rodam em sequência, na ordem do corpo
+	want := map[string][]string{"10": {"0", "2", "4"}, "20": {"1", "5"}, "30": {"3"}}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v; want %v", order, want)
+	}
+	if maxRunning > 2 {
+		t.Fatalf("%d items ran at the same time; limit is 2", maxRunning)
+	}
+	if b.success != len(keys) || len(b.results) != len(keys) {
+		t.Fatalf("success = %d, results = %d; want %d", b.success, len(b.results), len(keys))
+	}
+}
+
+func TestBulkRunDeadline(t *testing.T) {
+	keys := []string{"0", "1", "2"}
+	lanes := []string{"a", "b", "a"}
+
+	b := testBulkRun(30 * time.Millisecond)
+	var itemErr error
+	b.run(context.Background(), 1, keys, lanes, func(ctx context.Context, i int) {
+		if i == 0 {
+			// o item iniciado passa do prazo da requisição e termina
+			time.Sleep(80 * time.Millisecond)
+			itemErr = ctx.Err()
+		}
+		b.set(keys[i], "ok", true)
+	})
+
+	if itemErr != nil {
+		t.Fatalf("started item was cancelled: %v", itemErr)
+	}
+	if b.results["0"] != "ok" {
+		t.Fatalf("result 0 = %v; want ok", b.results["0"])
+	}
+	// '2' aguardava na lane do item 0 e '1' aguardava uma vaga
+	for _, key := range []string{"1", "2"} {
+		if status := resultStatus(t, b, key); status != http.StatusGatewayTimeout {
+			t.Fatalf("result %s status = %d; want 504", key, status)
+		}
+	}
+	if b.success != 1 {
+		t.Fatalf("success = %d; want 1", b.success)
+	}
+}
+
+func TestBulkRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	keys := []string{"0", "1", "2"}
+
+	b := testBulkRun(0)
+	b.run(ctx, 1, keys, keys, func(ctx context.Context, i int) {
+		if i == 0 {
+			cancel()
+		}
+		if ctx.Err() != nil {
+			t.Errorf("item %d started with a cancelled context", i)
+		}
+		b.set(keys[i], "ok", true)
+	})
+
+	// após um cancelamento (e.g. job cancelado), os demais itens não rodam
+	// nem são reportados
+	if !reflect.DeepEqual(b.results, map[string]interface{}{"0": "ok"}) {
+		t.Fatalf("results = %v; want only item 0", b.results)
+	}
+}
+
+func TestBulkRunFail(t *testing.T) {
+	b := testBulkRun(0)
+	b.run(context.Background(), 2, []string{"7"}, []string{"7"}, func(ctx context.Context, i int) {
+		b.fail("7", http.StatusNotFound, errors.New("deal 7 not found"))
+	})
+
+	want := map[string]interface{}{"error": "deal 7 not found", "status": http.StatusNotFound}
+	if !reflect.DeepEqual(b.results["7"], want) {
+		t.Fatalf("result = %v; want %v", b.results["7"], want)
+	}
+	if got := BulkStatus(b.success, 1); got != "failure" {
+		t.Fatalf("status = %q; want failure", got)
+	}
+}
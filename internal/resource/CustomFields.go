@@ -0,0 +1,152 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"pipedrive_api_service/internal/client"
+)
+
+// Campos customizados podem ser informados pela chave hash ou pelo nome
+// exibido no Pipedrive (o mesmo 'name' de custom_fields[] na leitura). As
+// chaves variam entre contas (sandbox/produção); os nomes, não.
+
+// Campos compostos usam chaves auxiliares (e.g. '<chave>_until' em daterange,
+// '<chave>_currency' em monetary), aceitas como estão
+var customFieldAuxKeyPattern = regexp.MustCompile(`^[0-9a-f]{40}_[a-z_]+$`)
+
+// customFieldsByName indexa os campos customizados pelo nome normalizado
+func customFieldsByName(fieldsMeta map[string]FieldMeta) map[string][]FieldMeta {
+	index := make(map[string][]FieldMeta)
+	for key, meta := range fieldsMeta {
+		if !customFieldKeyPattern.MatchString(key) {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(meta.Name))
+		index[name] = append(index[name], meta)
+	}
+	return index
+}
+
+// lookupCustomField resolve uma chave hash ou nome para a chave do campo
+func lookupCustomField(index map[string][]FieldMeta, fieldsMeta map[string]FieldMeta, ref string) (string, error) {
+	if _, ok := fieldsMeta[ref]; ok && customFieldKeyPattern.MatchString(ref) {
+		return ref, nil
+	}
+	if customFieldAuxKeyPattern.MatchString(ref) {
+		return ref, nil
+	}
+	matches := index[strings.ToLower(strings.TrimSpace(ref))]
+	switch len(matches) {
+	case 0:
+		if customFieldKeyPattern.MatchString(ref) {
+			return ref, nil
+		}
+		return "", fmt.Errorf("unknown custom field '%s'", ref)
+	case 1:
+		return matches[0].Key, nil
+	default:
+		keys := make([]string, 0, len(matches))
+		for _, m := range matches {
+			keys = append(keys, m.Key)
+		}
+		sort.Strings(keys)
+		return "", fmt.Errorf("ambiguous custom field name '%s': matches %s; use the field key", ref, strings.Join(keys, ", "))
+	}
+}
+
+// customFieldEntries normaliza 'custom_fields' em pares referência/valor.
+// Aceita {"nome ou chave": valor} ou a lista da leitura [{id|name, value}].
+func customFieldEntries(raw interface{}) (map[string]interface{}, error) {
+	switch v := raw.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return v, nil
+	case []interface{}:
+		entries := make(map[string]interface{}, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("custom_fields entries must be objects with 'id' or 'name' and 'value'")
+			}
+			ref, _ := m["id"].(string)
+			if ref == "" {
+				ref, _ = m["name"].(string)
+			}
+			if ref == "" {
+				return nil, fmt.Errorf("custom_fields entries must be objects with 'id' or 'name' and 'value'")
+			}
+			entries[ref] = m["value"]
+		}
+		return entries, nil
+	default:
+		return nil, fmt.Errorf("custom_fields must be an object or a list")
+	}
+}
+
+// resolveCustomFields achata 'custom_fields' no nível raiz trocando nomes por
+// chaves (nome desconhecido ou ambíguo é erro). Nas demais chaves, um nome de
+// campo customizado também é traduzido; as outras seguem como campos padrão.
+// Por fim, rótulos de opções enum/set são trocados pelos IDs.
+func (res *Resource[T, C]) resolveCustomFields(ctx context.Context, c *client.PipedriveClient, fields map[string]interface{}) error {
+	raw, hasCustom := fields["custom_fields"]
+	delete(fields, "custom_fields")
+
+	var entries map[string]interface{}
+	if hasCustom {
+		parsed, err := customFieldEntries(raw)
+		if err != nil {
+			return err
+		}
+		entries = parsed
+	}
+
+	fieldsMeta, err := res.FetchFields(ctx, c)
+	if err != nil {
+		if len(entries) == 0 {
+			return nil
+		}
+		for ref := range entries {
+			if !customFieldKeyPattern.MatchString(ref) {
+				return fmt.Errorf("cannot resolve custom field '%s': %w", ref, err)
+			}
+		}
+		for ref, v := range entries {
+			fields[ref] = v
+		}
+		return nil
+	}
+	index := customFieldsByName(fieldsMeta)
+
+	renamed := make(map[string]string)
+	for k := range fields {
+		if _, known := fieldsMeta[k]; known || customFieldKeyPattern.MatchString(k) {
+			continue
+		}
+		if len(index[strings.ToLower(strings.TrimSpace(k))]) == 0 {
+			continue
+		}
+		key, err := lookupCustomField(index, fieldsMeta, k)
+		if err != nil {
+			return err
+		}
+		renamed[k] = key
+	}
+	for name, key := range renamed {
+		fields[key] = fields[name]
+		delete(fields, name)
+	}
+
+	for ref, v := range entries {
+		key, err := lookupCustomField(index, fieldsMeta, ref)
+		if err != nil {
+			return err
+		}
+		fields[key] = v
+	}
+	return resolveOptionLabels(fieldsMeta, fields)
+}
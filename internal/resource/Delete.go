@@ -0,0 +1,182 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/utils"
+)
+
+// HandleDelete removes one or multiple records by ID. With ?dry_run=true it
+// only reports whether each record exists; with ?async=true it runs as a job.
+func (res *Resource[T, C]) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	bodyRaw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "failed to read request body",
+			"detail":  err.Error(),
+		}, nil)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(bodyRaw) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message":        "request body is empty",
+			"hint":           fmt.Sprintf("provide at least one %s ID to delete", res.Singular),
+			"example_single": map[string]int{"id": 123},
+			"example_bulk":   []int{123, 124, 125},
+		}, nil)
+		return
+	}
+
+	// Suporta: [1,2,3] ou { "id": 123 }
+	var ids []int
+	if err := json.Unmarshal(bodyRaw, &ids); err != nil {
+		var single map[string]interface{}
+		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil {
+			if idVal, ok := single["id"]; ok {
+				switch v := idVal.(type) {
+				case float64:
+					ids = append(ids, int(v))
+				case int:
+					ids = append(ids, v)
+				case string:
+					if idNum, err := strconv.Atoi(v); err == nil {
+						ids = append(ids, idNum)
+					}
+				}
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": fmt.Sprintf("no valid %s IDs found in body", res.Singular),
+			"hint":    "body must contain a numeric 'id' or a list of IDs",
+		}, nil)
+		return
+	}
+
+	concurrency, err := bulkConcurrency(r)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	dryRun := res.dryRun(r)
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = strconv.Itoa(id)
+	}
+
+	url := c.BaseURL() + res.Path + dryRunOperation(" (delete bulk)", dryRun)
+	bulk := runBulk(w, r, start, url, keys, func(ctx context.Context, bulk *bulkRun) {
+		bulk.run(ctx, concurrency, keys, keys, func(ctx context.Context, i int) {
+			id, idStr := ids[i], keys[i]
+			if id <= 0 {
+				bulk.set(idStr, map[string]interface{}{
+					"error":  "invalid id",
+					"status": http.StatusBadRequest,
+				}, false)
+				return
+			}
+
+			if dryRun {
+				preview, status, err := res.previewDelete(ctx, c, id)
+				if err != nil {
+					failure := map[string]interface{}{
+						"error":  err.Error(),
+						"status": status,
+					}
+					if extra, ok := preview.(map[string]interface{}); ok {
+						for k, v := range extra {
+							failure[k] = v
+						}
+					}
+					bulk.set(idStr, failure, false)
+					return
+				}
+				bulk.set(idStr, preview, true)
+				return
+			}
+
+			status, detail, err := res.deleteOne(ctx, c, id)
+			if err != nil {
+				failure := map[string]interface{}{
+					"error":  err.Error(),
+					"status": status,
+				}
+				if status != http.StatusServiceUnavailable {
+					failure["detail"] = detail
+				}
+				bulk.set(idStr, failure, false)
+				return
+			}
+
+			bulk.set(idStr, map[string]interface{}{
+				"success": true,
+				"deleted": id,
+			}, true)
+		})
+	})
+	if bulk == nil {
+		return
+	}
+	successCount := bulk.success
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		url,
+		http.StatusOK,
+		nil,
+	)
+
+	summary := map[string]int{
+		"requested": len(ids),
+		"deleted":   successCount,
+		"failed":    len(ids) - successCount,
+	}
+	data := map[string]interface{}{
+		"status":  BulkStatus(successCount, len(ids)),
+		"summary": summary,
+		"results": bulk.results,
+	}
+	if dryRun {
+		delete(summary, "deleted")
+		summary["found"] = successCount
+		data["dry_run"] = true
+	}
+	utils.JSONOK(w, data, meta)
+}
+
+// deleteOne remove um registro; em caso de erro retorna o status e o campo
+// 'error' devolvido pelo Pipedrive
+func (res *Resource[T, C]) deleteOne(ctx context.Context, c *client.PipedriveClient, id int) (int, interface{}, error) {
+	path := fmt.Sprintf("%s/%d", res.Path, id)
+	reqCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.Do(reqCtx, utils.HTTPDelete, path, nil)
+	if err != nil || resp == nil {
+		return http.StatusServiceUnavailable, nil, fmt.Errorf("failed to reach upstream Pipedrive: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var parsed map[string]interface{}
+		_ = json.Unmarshal(body, &parsed)
+		return resp.StatusCode, parsed["error"], fmt.Errorf("upstream returned %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil, nil
+}
@@ -0,0 +1,177 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"pipedrive_api_service/internal/client"
+)
+
+// Dry run (?dry_run=true): as rotas em massa validam os itens, resolvem IDs e
+// leem os valores atuais, mas não enviam nenhuma escrita ao Pipedrive. Cada
+// item retorna uma prévia do que seria feito.
+
+func (res *Resource[T, C]) dryRun(r *http.Request) bool {
+	if !res.DryRun {
+		return false
+	}
+	dry, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	return dry
+}
+
+// dryRunOperation acrescenta "dry run" ao sufixo da URL do MetaItem
+func dryRunOperation(operation string, dry bool) string {
+	if !dry {
+		return operation
+	}
+	return strings.TrimSuffix(operation, ")") + ", dry run)"
+}
+
+// sameValue compara um valor atual do Pipedrive com o valor enviado; escalares
+// também são comparados como texto (e.g. 1000 e "1000")
+func sameValue(a, b interface{}) bool {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	if string(ja) == string(jb) {
+		return true
+	}
+	if isScalar(a) && isScalar(b) {
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	}
+	return false
+}
+
+func isScalar(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return false
+	}
+	return true
+}
+
+// fieldChanges retorna {campo: {before, after}} para os campos que mudariam
+// e a lista dos que já têm o valor enviado
+func fieldChanges(current, fields map[string]interface{}) (map[string]interface{}, []string) {
+	changes := make(map[string]interface{})
+	unchanged := make([]string, 0)
+	for k, v := range fields {
+		if sameValue(current[k], v) {
+			unchanged = append(unchanged, k)
+			continue
+		}
+		changes[k] = map[string]interface{}{
+			"before": current[k],
+			"after":  v,
+		}
+	}
+	sort.Strings(unchanged)
+	return changes, unchanged
+}
+
+// fetchExisting lê o registro atual; 404/410 viram "<recurso> {id} not found"
+func (res *Resource[T, C]) fetchExisting(ctx context.Context, c *client.PipedriveClient, id int) (map[string]interface{}, int, error) {
+	current, status, err := res.FetchAsMap(ctx, c, id, nil)
+	if status == http.StatusNotFound || status == http.StatusGone || (err == nil && current == nil) {
+		return nil, http.StatusNotFound, fmt.Errorf("%s %d not found", res.Singular, id)
+	}
+	if err != nil {
+		return nil, status, err
+	}
+	return current, http.StatusOK, nil
+}
+
+// previewUpdate mostra o antes/depois de um item do PUT em massa
+func (res *Resource[T, C]) previewUpdate(ctx context.Context, c *client.PipedriveClient, it UpdateItem) (interface{}, int, error) {
+	fields, status, err := res.prepareUpdate(ctx, c, it)
+	if err != nil {
+		return nil, status, err
+	}
+	return res.previewReplace(ctx, c, it.ID, fields)
+}
+
+func (res *Resource[T, C]) previewReplace(ctx context.Context, c *client.PipedriveClient, id int, fields map[string]interface{}) (map[string]interface{}, int, error) {
+	current, status, err := res.fetchExisting(ctx, c, id)
+	if err != nil {
+		return nil, status, err
+	}
+	changes, unchanged := fieldChanges(current, fields)
+	return map[string]interface{}{
+		"action":    "update",
+		"id":        id,
+		"changes":   changes,
+		"unchanged": unchanged,
+	}, http.StatusOK, nil
+}
+
+// previewCreate mostra o payload que seria enviado na criação
+func previewCreate(payload map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"action":  "create",
+		"payload": payload,
+	}
+}
+
+// previewUpsert resolve a chave natural como upsertOne, sem gravar. 'planned'
+// guarda as chaves que um item anterior da mesma requisição criaria.
+func (res *Resource[T, C]) previewUpsert(ctx context.Context, c *client.PipedriveClient, key string, payload map[string]interface{}, planned map[string]bool) (interface{}, int, error) {
+	value, ok := payload[key]
+	if !ok || isBlank(value) {
+		return nil, http.StatusBadRequest, fmt.Errorf("upsert key '%s' is missing in item", key)
+	}
+	plannedKey := strings.ToLower(strings.TrimSpace(fmt.Sprint(value)))
+
+	if planned[plannedKey] {
+		if status, err := res.validateFieldTypes(ctx, c, payload, res.ReadOnly); err != nil {
+			return nil, status, err
+		}
+		return map[string]interface{}{
+			"action":  "update",
+			"target":  "created by an earlier item in this request",
+			"payload": payload,
+		}, http.StatusOK, nil
+	}
+
+	matches, status, err := res.findByKey(ctx, c, key, value)
+	if err != nil {
+		return nil, status, err
+	}
+
+	switch len(matches) {
+	case 0:
+		if err := res.resolveCreateOwner(ctx, c, payload); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		planned[plannedKey] = true
+		return previewCreate(payload), http.StatusOK, nil
+	case 1:
+		return res.previewUpdate(ctx, c, upsertUpdate(matches[0], payload))
+	default:
+		return map[string]interface{}{
+			"matches": matches,
+		}, http.StatusConflict, fmt.Errorf("conflict: %d %s match %s='%v'", len(matches), res.Name, key, value)
+	}
+}
+
+// previewDelete confirma que o registro existe
+func (res *Resource[T, C]) previewDelete(ctx context.Context, c *client.PipedriveClient, id int) (interface{}, int, error) {
+	if _, status, err := res.fetchExisting(ctx, c, id); err != nil {
+		if status == http.StatusNotFound {
+			return map[string]interface{}{"exists": false}, status, err
+		}
+		return nil, status, err
+	}
+	return map[string]interface{}{
+		"action": "delete",
+		"id":     id,
+		"exists": true,
+	}, http.StatusOK, nil
+}
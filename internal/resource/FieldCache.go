@@ -0,0 +1,172 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/utils"
+)
+
+// DefaultFieldCacheTTL é o tempo de vida padrão dos metadados de campos
+const DefaultFieldCacheTTL = 10 * time.Minute
+
+// fieldLoadTimeout limita a carga compartilhada por todas as requisições que
+// esperam pelo cache
+const fieldLoadTimeout = 30 * time.Second
+
+var (
+	fieldCacheTTLMu sync.RWMutex
+	fieldCacheTTL   = DefaultFieldCacheTTL
+)
+
+// SetFieldCacheTTL define o tempo de vida dos caches de campos
+func SetFieldCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultFieldCacheTTL
+	}
+	fieldCacheTTLMu.Lock()
+	fieldCacheTTL = ttl
+	fieldCacheTTLMu.Unlock()
+}
+
+func currentFieldCacheTTL() time.Duration {
+	fieldCacheTTLMu.RLock()
+	defer fieldCacheTTLMu.RUnlock()
+	return fieldCacheTTL
+}
+
+// fieldLoad é uma carga em andamento; quem chega durante a carga espera por
+// ela em vez de disparar outra (single-flight)
+type fieldLoad struct {
+	done   chan struct{}
+	fields map[string]FieldMeta
+	err    error
+}
+
+// fieldCache guarda os metadados de campos de um recurso. O mapa publicado
+// nunca é alterado: uma recarga troca o mapa inteiro.
+type fieldCache struct {
+	mu         sync.Mutex
+	fields     map[string]FieldMeta
+	loadedAt   time.Time
+	loading    *fieldLoad
+	generation int
+}
+
+// FetchFields retorna os metadados dos campos do cache, recarregando-os de
+// FieldsPath quando expiraram. Se a recarga falhar, a versão anterior continua
+// sendo usada.
+func (res *Resource[T, C]) FetchFields(ctx context.Context, c *client.PipedriveClient) (map[string]FieldMeta, error) {
+	cache := &res.fields
+
+	cache.mu.Lock()
+	if cache.fields != nil && time.Since(cache.loadedAt) < currentFieldCacheTTL() {
+		fields := cache.fields
+		cache.mu.Unlock()
+		return fields, nil
+	}
+	load := cache.loading
+	if load == nil {
+		load = &fieldLoad{done: make(chan struct{})}
+		cache.loading = load
+		go res.loadFields(c, load, cache.generation)
+	}
+	cache.mu.Unlock()
+
+	select {
+	case <-load.done:
+		return load.fields, load.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// loadFields carrega os campos fora do contexto da requisição, para que o
+// cancelamento de um cliente não afete os demais que esperam pela carga
+func (res *Resource[T, C]) loadFields(c *client.PipedriveClient, load *fieldLoad, generation int) {
+	ctx, cancel := context.WithTimeout(context.Background(), fieldLoadTimeout)
+	defer cancel()
+
+	fields, err := res.fetchFieldsMeta(ctx, c)
+
+	cache := &res.fields
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.loading == load {
+		cache.loading = nil
+	}
+	switch {
+	case err == nil && generation == cache.generation:
+		cache.fields = fields
+		cache.loadedAt = time.Now()
+	case err != nil && cache.fields != nil:
+		log.Printf("%s: keeping cached fields after refresh failure: %v", res.FieldsPath, err)
+		fields, err = cache.fields, nil
+	}
+
+	load.fields, load.err = fields, err
+	close(load.done)
+}
+
+func (res *Resource[T, C]) fetchFieldsMeta(ctx context.Context, c *client.PipedriveClient) (map[string]FieldMeta, error) {
+	defs, _, _, err := res.fetchFieldDefinitions(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, _ := json.Marshal(defs)
+	var list []FieldMeta
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", res.FieldsPath[1:], err)
+	}
+
+	fields := make(map[string]FieldMeta, len(list))
+	for _, f := range list {
+		fields[f.Key] = f
+	}
+	return fields, nil
+}
+
+// InvalidateFields descarta o cache; a próxima leitura recarrega os campos.
+// Uma carga em andamento não publica o resultado antigo.
+func (res *Resource[T, C]) InvalidateFields() {
+	cache := &res.fields
+	cache.mu.Lock()
+	cache.fields = nil
+	cache.generation++
+	cache.loading = nil
+	cache.mu.Unlock()
+}
+
+// RefreshFields força a recarga dos campos e retorna a quantidade carregada
+func (res *Resource[T, C]) RefreshFields(ctx context.Context, c *client.PipedriveClient) (int, error) {
+	res.InvalidateFields()
+	fields, err := res.FetchFields(ctx, c)
+	return len(fields), err
+}
+
+// FieldsAge retorna a idade do cache de campos; false quando está vazio
+func (res *Resource[T, C]) FieldsAge() (time.Duration, bool) {
+	cache := &res.fields
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.fields == nil {
+		return 0, false
+	}
+	return time.Since(cache.loadedAt), true
+}
+
+// withFieldCacheAge acrescenta ao MetaItem a idade do cache de campos
+func (res *Resource[T, C]) withFieldCacheAge(meta *utils.MetaItem) *utils.MetaItem {
+	if age, ok := res.FieldsAge(); ok {
+		ms := age.Milliseconds()
+		meta.FieldCacheAgeMs = &ms
+	}
+	return meta
+}
@@ -0,0 +1,250 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+)
+
+// Validação pelo tipo real do campo (FieldMeta.FieldType), feita antes de
+// qualquer escrita. Valores vazios (null ou "") limpam o campo e não são
+// validados.
+
+// typeValidator valida o valor de um campo; 'fields' é o corpo inteiro, para
+// tipos com campos auxiliares (e.g. '<chave>_until' em daterange)
+type typeValidator func(meta FieldMeta, value interface{}, fields map[string]interface{}) string
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9 ().-]+$`)
+
+var typeValidators = map[string]typeValidator{
+	"double":    validateNumber,
+	"monetary":  validateNumber,
+	"date":      validateDate,
+	"daterange": validateDateRange,
+	"time":      validateTime,
+	"phone":     validatePhone,
+	"enum":      validateEnum,
+	"set":       validateSet,
+	"user":      validateRefID,
+	"org":       validateRefID,
+	"people":    validateRefID,
+	"stage":     validateRefID,
+	"price":     validatePrices,
+}
+
+func validateNumber(_ FieldMeta, value interface{}, _ map[string]interface{}) string {
+	if !isScalar(value) {
+		return "expected a number"
+	}
+	if _, err := strconv.ParseFloat(strings.TrimSpace(fmt.Sprint(value)), 64); err != nil {
+		return "expected a number"
+	}
+	return ""
+}
+
+func parseDate(value interface{}) (time.Time, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(s))
+	return t, err == nil
+}
+
+func validateDate(_ FieldMeta, value interface{}, _ map[string]interface{}) string {
+	if _, ok := parseDate(value); !ok {
+		return "expected a date in YYYY-MM-DD format"
+	}
+	return ""
+}
+
+// validateDateRange valida o início (chave do campo) e o fim ('<chave>_until')
+func validateDateRange(meta FieldMeta, value interface{}, fields map[string]interface{}) string {
+	start, ok := parseDate(value)
+	if !ok {
+		return "expected a start date in YYYY-MM-DD format"
+	}
+	until, present := fields[meta.Key+"_until"]
+	if !present || isBlank(until) {
+		return ""
+	}
+	end, ok := parseDate(until)
+	if !ok {
+		return fmt.Sprintf("expected '%s_until' in YYYY-MM-DD format", meta.Key)
+	}
+	if end.Before(start) {
+		return fmt.Sprintf("'%s_until' must not be before the start date", meta.Key)
+	}
+	return ""
+}
+
+func validateTime(_ FieldMeta, value interface{}, _ map[string]interface{}) string {
+	s, ok := value.(string)
+	if ok {
+		s = strings.TrimSpace(s)
+		if _, err := time.Parse("15:04", s); err == nil {
+			return ""
+		}
+		if _, err := time.Parse("15:04:05", s); err == nil {
+			return ""
+		}
+	}
+	return "expected a time in HH:MM or HH:MM:SS format"
+}
+
+// validatePhone aceita texto ou a lista [{value, primary, label}] de pessoas
+func validatePhone(_ FieldMeta, value interface{}, _ map[string]interface{}) string {
+	numbers := make([]string, 0, 1)
+	switch v := value.(type) {
+	case string:
+		numbers = append(numbers, v)
+	case []interface{}:
+		for _, entry := range v {
+			if m, ok := entry.(map[string]interface{}); ok {
+				numbers = append(numbers, fmt.Sprint(m["value"]))
+				continue
+			}
+			numbers = append(numbers, fmt.Sprint(entry))
+		}
+	default:
+		return "expected a phone number"
+	}
+
+	for _, n := range numbers {
+		n = strings.TrimSpace(n)
+		digits := 0
+		for _, r := range n {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if !phonePattern.MatchString(n) || digits < 6 {
+			return fmt.Sprintf("invalid phone number '%s'", n)
+		}
+	}
+	return ""
+}
+
+// intValue aceita inteiros vindos do JSON (float64) ou como texto
+func intValue(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int(n), n == float64(int(n))
+	case int:
+		return n, true
+	case string:
+		id, err := strconv.Atoi(strings.TrimSpace(n))
+		return id, err == nil
+	}
+	return 0, false
+}
+
+func validateOption(meta FieldMeta, v interface{}) string {
+	id, ok := intValue(v)
+	if !ok {
+		return fmt.Sprintf("invalid option '%v'", v)
+	}
+	if len(meta.Options) == 0 {
+		return ""
+	}
+	for _, opt := range meta.Options {
+		if opt.ID == id {
+			return ""
+		}
+	}
+	return fmt.Sprintf("unknown option id %d", id)
+}
+
+func validateEnum(meta FieldMeta, value interface{}, _ map[string]interface{}) string {
+	if !isScalar(value) {
+		return "expected a single option"
+	}
+	return validateOption(meta, value)
+}
+
+func validateSet(meta FieldMeta, value interface{}, _ map[string]interface{}) string {
+	for _, v := range optionValues(meta, value) {
+		if msg := validateOption(meta, v); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// validateRefID exige o ID (inteiro positivo) do usuário, organização ou pessoa
+func validateRefID(meta FieldMeta, value interface{}, _ map[string]interface{}) string {
+	if id, ok := intValue(value); ok && id > 0 {
+		return ""
+	}
+	return fmt.Sprintf("expected a %s id", meta.FieldType)
+}
+
+// validatePrices exige a lista de preços de produtos ([{currency, price}])
+func validatePrices(_ FieldMeta, value interface{}, _ map[string]interface{}) string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return "expected a list of {currency, price}"
+	}
+	for _, entry := range list {
+		if _, ok := entry.(map[string]interface{}); !ok {
+			return "expected a list of {currency, price}"
+		}
+	}
+	return ""
+}
+
+// fieldLabel identifica o campo na mensagem: campos customizados pelo nome
+func fieldLabel(meta FieldMeta) string {
+	if customFieldKeyPattern.MatchString(meta.Key) {
+		return fmt.Sprintf("'%s' (%s)", meta.Name, meta.Key)
+	}
+	return fmt.Sprintf("'%s'", meta.Key)
+}
+
+// validateFieldTypes valida cada campo pelo tipo em FieldsPath e reporta todos
+// os campos inválidos de uma vez (400), em ordem alfabética. Campos em
+// 'readOnly' (ReadOnly nas atualizações, nil na criação) entram na mesma lista.
+// Sem os metadados, o item falha com 503 em vez de seguir sem validação.
+func (res *Resource[T, C]) validateFieldTypes(ctx context.Context, c *client.PipedriveClient, fields map[string]interface{}, readOnly map[string]bool) (int, error) {
+	fieldsMeta, err := res.FetchFields(ctx, c)
+	if err != nil {
+		return http.StatusServiceUnavailable, fmt.Errorf("cannot validate %s fields: %w", res.Singular, err)
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	failures := make([]string, 0)
+	for _, key := range keys {
+		if readOnly[key] {
+			failures = append(failures, fmt.Sprintf("field '%s' is read-only and cannot be modified", key))
+			continue
+		}
+		value := fields[key]
+		meta, ok := fieldsMeta[key]
+		if !ok || isBlank(value) {
+			continue
+		}
+		validate, ok := typeValidators[meta.FieldType]
+		if !ok {
+			continue
+		}
+		if msg := validate(meta, value, fields); msg != "" {
+			failures = append(failures, fmt.Sprintf("invalid value for %s: %s", fieldLabel(meta), msg))
+		}
+	}
+	if len(failures) > 0 {
+		return http.StatusBadRequest, errors.New(strings.Join(failures, "; "))
+	}
+	return http.StatusOK, nil
+}
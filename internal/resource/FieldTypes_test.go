@@ -0,0 +1,114 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"pipedrive_api_service/internal/client"
+)
+
+func TestValidateFieldTypes(t *testing.T) {
+	readOnly := map[string]bool{"id": true, "add_time": true}
+
+	tests := []struct {
+		name       string
+		fields     string
+		readOnly   map[string]bool
+		wantStatus int
+		wantErr    string
+	}{
+		{
+			name:       "valid values",
+			fields:     `{"value": "1500.50", "expected": "2026-01-31", "stage_id": 3, "status": 1}`,
+			readOnly:   readOnly,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "blank values clear the field",
+			fields:     `{"value": "", "expected": null}`,
+			readOnly:   readOnly,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown fields are not validated",
+			fields:     `{"whatever": [1, 2]}`,
+			readOnly:   readOnly,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid number",
+			fields:     `{"probability": "alto"}`,
+			readOnly:   readOnly,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    "invalid value for 'probability': expected a number",
+		},
+		{
+			name:       "custom field reported by name",
+			fields:     `{"` + keySegmento + `": 99}`,
+			readOnly:   readOnly,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    "invalid value for 'Segmento' (" + keySegmento + "): unknown option id 99",
+		},
+		{
+			name:       "read-only field",
+			fields:     `{"id": 5}`,
+			readOnly:   readOnly,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    "field 'id' is read-only and cannot be modified",
+		},
+		{
+			name:       "read-only and type errors in one sorted list",
+			fields:     `{"value": "abc", "add_time": "2026-01-01", "expected": "31/01/2026"}`,
+			readOnly:   readOnly,
+			wantStatus: http.StatusBadRequest,
+			wantErr: "field 'add_time' is read-only and cannot be modified; " +
+				"invalid value for 'expected': expected a date in YYYY-MM-DD format; " +
+				"invalid value for 'value': expected a number",
+		},
+		{
+			name:       "read-only keys are accepted on create",
+			fields:     `{"add_time": "2026-01-01"}`,
+			readOnly:   nil,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	res := testResource(testFields())
+	c := client.NewPipedriveClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, _ := decodeJSON(t, tt.fields).(map[string]interface{})
+			status, err := res.validateFieldTypes(context.Background(), c, fields, tt.readOnly)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d; want %d (err: %v)", status, tt.wantStatus, err)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("error = %v; want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateFieldTypesWithoutMetadata(t *testing.T) {
+	_, c := setupPipedrive(t, map[string]http.HandlerFunc{
+		"/dealFields": respondJSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": "boom"}),
+	})
+	res := testResource(nil)
+
+	// sem metadados o item falha, em vez de seguir sem validação
+	status, err := res.validateFieldTypes(context.Background(), c, map[string]interface{}{"value": "abc"}, res.ReadOnly)
+	if status != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d; want 503", status)
+	}
+	if err == nil || !strings.Contains(err.Error(), "cannot validate deal fields") {
+		t.Fatalf("error = %v; want the metadata failure", err)
+	}
+}
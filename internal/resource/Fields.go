@@ -0,0 +1,416 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/utils"
+)
+
+// Gestão das definições de campos (/pipedrive/fields/{entity}). Toda
+// alteração bem-sucedida invalida o cache usado por FetchFields.
+
+// FieldTypes aceitos na criação de campos customizados
+var creatableFieldTypes = map[string]bool{
+	"varchar": true, "varchar_auto": true, "text": true, "double": true,
+	"monetary": true, "date": true, "daterange": true, "time": true,
+	"timerange": true, "phone": true, "enum": true, "set": true,
+	"user": true, "org": true, "people": true, "address": true,
+}
+
+// FieldDefinition é o corpo de criação/atualização de um campo
+type FieldDefinition struct {
+	ID        int           `json:"id,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	FieldType string        `json:"field_type,omitempty"`
+	Options   []FieldOption `json:"options,omitempty"`
+}
+
+// fetchFieldDefinitions lê todas as páginas de FieldsPath
+func (res *Resource[T, C]) fetchFieldDefinitions(ctx context.Context, c *client.PipedriveClient) ([]map[string]interface{}, *utils.RateLimitInfo, int, error) {
+	fields := make([]map[string]interface{}, 0)
+	rate := &utils.RateLimitInfo{}
+	limit := res.pageLimit()
+	offset := 0
+
+	for {
+		query := url.Values{}
+		query.Set("start", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(limit))
+
+		resp, body, pageRate, err := c.Do(ctx, utils.HTTPGet, res.FieldsPath, query)
+		if err != nil {
+			return nil, rate, http.StatusServiceUnavailable, fmt.Errorf("failed to fetch %s fields: %w", res.Singular, err)
+		}
+		resp.Body.Close()
+		if pageRate != nil {
+			rate = pageRate
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			var parsed map[string]interface{}
+			_ = json.Unmarshal(body, &parsed)
+			return nil, rate, resp.StatusCode, fmt.Errorf("upstream returned %d while fetching %s: %s", resp.StatusCode, res.FieldsPath[1:], ExtractUpstreamError(parsed))
+		}
+
+		var page struct {
+			Data           []map[string]interface{} `json:"data"`
+			AdditionalData struct {
+				Pagination struct {
+					MoreItemsInCollection bool `json:"more_items_in_collection"`
+					NextStart             int  `json:"next_start"`
+				} `json:"pagination"`
+			} `json:"additional_data"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, rate, http.StatusInternalServerError, fmt.Errorf("failed to parse %s: %w", res.FieldsPath[1:], err)
+		}
+		fields = append(fields, page.Data...)
+
+		pagination := page.AdditionalData.Pagination
+		if !pagination.MoreItemsInCollection || pagination.NextStart <= offset {
+			return fields, rate, http.StatusOK, nil
+		}
+		offset = pagination.NextStart
+	}
+}
+
+// HandleFieldsGet lista as definições de campos (todas as páginas). Com
+// ?custom_only=true, apenas os campos customizados.
+func (res *Resource[T, C]) HandleFieldsGet(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+	customOnly, _ := strconv.ParseBool(r.URL.Query().Get("custom_only"))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	all, rate, status, err := res.fetchFieldDefinitions(ctx, c)
+	meta := utils.NewMetaItem(start, r.Header.Get(utils.HeaderXRequestID), c.BaseURL()+res.FieldsPath, status, rate)
+	if err != nil {
+		utils.JSONError(w, status, err.Error(), meta)
+		return
+	}
+
+	fields := make([]map[string]interface{}, 0, len(all))
+	for _, f := range all {
+		if customOnly && !customFieldKeyPattern.MatchString(fmt.Sprint(f["key"])) {
+			continue
+		}
+		fields = append(fields, f)
+	}
+
+	meta.Extra = &utils.ExtraMeta{TotalResults: len(fields)}
+	utils.JSONOK(w, fields, meta)
+}
+
+// decodeFieldDefinitions aceita um objeto ou uma lista de definições
+func decodeFieldDefinitions(r *http.Request) ([]FieldDefinition, error) {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body")
+	}
+	defer r.Body.Close()
+
+	var defs []FieldDefinition
+	if err := json.Unmarshal(raw, &defs); err != nil {
+		var single FieldDefinition
+		if err2 := json.Unmarshal(raw, &single); err2 != nil {
+			return nil, fmt.Errorf("body must be a field definition or a list of field definitions")
+		}
+		defs = []FieldDefinition{single}
+	}
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("empty field definition list")
+	}
+	return defs, nil
+}
+
+func validateOptionLabels(options []FieldOption) error {
+	seen := make(map[string]bool, len(options))
+	for _, opt := range options {
+		label := strings.ToLower(strings.TrimSpace(opt.Label))
+		if label == "" {
+			return errors.New("option labels must not be empty")
+		}
+		if seen[label] {
+			return fmt.Errorf("duplicate option label '%s'", opt.Label)
+		}
+		seen[label] = true
+	}
+	return nil
+}
+
+// optionsPayload monta as opções enviadas ao Pipedrive; opções novas vão
+// sem 'id'
+func optionsPayload(options []FieldOption) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(options))
+	for _, opt := range options {
+		entry := map[string]interface{}{"label": strings.TrimSpace(opt.Label)}
+		if opt.ID > 0 {
+			entry["id"] = opt.ID
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// sendFieldChange envia uma alteração de definição e devolve o 'data'
+func (res *Resource[T, C]) sendFieldChange(ctx context.Context, c *client.PipedriveClient, method utils.HTTPMethod, path string, payload interface{}) (interface{}, int, error) {
+	var reader io.Reader
+	if payload != nil {
+		bodyBytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		reader = bytes.NewReader(bodyBytes)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.DoWithBody(reqCtx, method, path, nil, reader)
+	if err != nil || resp == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("failed to reach upstream Pipedrive: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+	if resp.StatusCode >= 400 {
+		return nil, resp.StatusCode, errors.New(ExtractUpstreamError(parsed))
+	}
+	return parsed["data"], resp.StatusCode, nil
+}
+
+// writeFieldResults responde o resultado em massa e invalida o cache quando
+// alguma definição mudou
+func (res *Resource[T, C]) writeFieldResults(w http.ResponseWriter, r *http.Request, c *client.PipedriveClient, start time.Time, operation string, status int, results map[string]interface{}, success, total int) {
+	if success > 0 {
+		res.InvalidateFields()
+	}
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+res.FieldsPath+operation,
+		status,
+		nil,
+	)
+	utils.JSONOK(w, map[string]interface{}{
+		"status":  BulkStatus(success, total),
+		"results": results,
+	}, meta)
+}
+
+// HandleFieldsPost cria um ou mais campos customizados ({name, field_type, options})
+func (res *Resource[T, C]) HandleFieldsPost(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	defs, err := decodeFieldDefinitions(r)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": err.Error(),
+			"example_single": map[string]interface{}{
+				"name":       "Plano",
+				"field_type": "enum",
+				"options":    []map[string]string{{"label": "Enterprise"}, {"label": "Starter"}},
+			},
+		}, nil)
+		return
+	}
+
+	results := make(map[string]interface{}, len(defs))
+	success := 0
+
+	for i, def := range defs {
+		indexKey := strconv.Itoa(i)
+		fail := func(status int, err error) {
+			results[indexKey] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": status,
+			}
+		}
+
+		if strings.TrimSpace(def.Name) == "" {
+			fail(http.StatusBadRequest, errors.New("field 'name' is required"))
+			continue
+		}
+		if !creatableFieldTypes[def.FieldType] {
+			types := make([]string, 0, len(creatableFieldTypes))
+			for t := range creatableFieldTypes {
+				types = append(types, t)
+			}
+			sort.Strings(types)
+			fail(http.StatusBadRequest, fmt.Errorf("invalid field_type '%s': use one of %s", def.FieldType, strings.Join(types, ", ")))
+			continue
+		}
+
+		payload := map[string]interface{}{
+			"name":       strings.TrimSpace(def.Name),
+			"field_type": def.FieldType,
+		}
+		if def.FieldType == fieldTypeEnum || def.FieldType == fieldTypeSet {
+			if len(def.Options) == 0 {
+				fail(http.StatusBadRequest, fmt.Errorf("field_type '%s' requires options", def.FieldType))
+				continue
+			}
+			if err := validateOptionLabels(def.Options); err != nil {
+				fail(http.StatusBadRequest, err)
+				continue
+			}
+			payload["options"] = optionsPayload(def.Options)
+		} else if len(def.Options) > 0 {
+			fail(http.StatusBadRequest, fmt.Errorf("options are only supported for enum and set fields"))
+			continue
+		}
+
+		data, status, err := res.sendFieldChange(r.Context(), c, utils.HTTPPost, res.FieldsPath, payload)
+		if err != nil {
+			fail(status, err)
+			continue
+		}
+		results[indexKey] = data
+		success++
+	}
+
+	res.writeFieldResults(w, r, c, start, " (create bulk)", http.StatusCreated, results, success, len(defs))
+}
+
+// HandleFieldsPut renomeia campos e/ou substitui suas opções ([{id, name, options}]).
+// Opções sem 'id' são criadas; opções existentes omitidas são removidas pelo Pipedrive.
+func (res *Resource[T, C]) HandleFieldsPut(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	defs, err := decodeFieldDefinitions(r)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": err.Error(),
+			"example_bulk": []map[string]interface{}{
+				{"id": 12, "name": "Plano contratado"},
+				{"id": 13, "options": []map[string]interface{}{{"id": 37, "label": "Enterprise"}, {"label": "Pro"}}},
+			},
+		}, nil)
+		return
+	}
+
+	results := make(map[string]interface{}, len(defs))
+	success := 0
+
+	for _, def := range defs {
+		idStr := strconv.Itoa(def.ID)
+		fail := func(status int, err error) {
+			results[idStr] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": status,
+			}
+		}
+
+		if def.ID <= 0 {
+			fail(http.StatusBadRequest, errors.New("invalid id"))
+			continue
+		}
+		if def.FieldType != "" {
+			fail(http.StatusBadRequest, errors.New("only 'name' and 'options' can be updated"))
+			continue
+		}
+
+		payload := map[string]interface{}{}
+		if name := strings.TrimSpace(def.Name); name != "" {
+			payload["name"] = name
+		}
+		if def.Options != nil {
+			if err := validateOptionLabels(def.Options); err != nil {
+				fail(http.StatusBadRequest, err)
+				continue
+			}
+			payload["options"] = optionsPayload(def.Options)
+		}
+		if len(payload) == 0 {
+			fail(http.StatusBadRequest, errors.New("provide 'name' and/or 'options' to update"))
+			continue
+		}
+
+		data, status, err := res.sendFieldChange(r.Context(), c, utils.HTTPPut, fmt.Sprintf("%s/%d", res.FieldsPath, def.ID), payload)
+		if err != nil {
+			fail(status, err)
+			continue
+		}
+		results[idStr] = data
+		success++
+	}
+
+	res.writeFieldResults(w, r, c, start, " (bulk)", http.StatusMultiStatus, results, success, len(defs))
+}
+
+// HandleFieldsDelete remove campos customizados por ID ([1, 2] ou {"id": 1})
+func (res *Resource[T, C]) HandleFieldsDelete(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "failed to read request body", nil)
+		return
+	}
+	defer r.Body.Close()
+
+	var ids []int
+	if err := json.Unmarshal(raw, &ids); err != nil {
+		var single struct {
+			ID int `json:"id"`
+		}
+		if err2 := json.Unmarshal(raw, &single); err2 == nil && single.ID != 0 {
+			ids = []int{single.ID}
+		}
+	}
+	if len(ids) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message":        "no valid field IDs found in body",
+			"example_single": map[string]int{"id": 12},
+			"example_bulk":   []int{12, 13},
+		}, nil)
+		return
+	}
+
+	results := make(map[string]interface{}, len(ids))
+	success := 0
+
+	for _, id := range ids {
+		idStr := strconv.Itoa(id)
+		if id <= 0 {
+			results[idStr] = map[string]interface{}{
+				"error":  "invalid id",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		if _, status, err := res.sendFieldChange(r.Context(), c, utils.HTTPDelete, fmt.Sprintf("%s/%d", res.FieldsPath, id), nil); err != nil {
+			results[idStr] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": status,
+			}
+			continue
+		}
+		results[idStr] = map[string]interface{}{
+			"success": true,
+			"deleted": id,
+		}
+		success++
+	}
+
+	res.writeFieldResults(w, r, c, start, " (delete bulk)", http.StatusOK, results, success, len(ids))
+}
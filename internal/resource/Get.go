@@ -0,0 +1,391 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/utils"
+)
+
+// groupCustomFields move os campos customizados (chaves hash) para a lista
+// 'custom_fields', com nome e tipo de cada um. Campos enum trazem 'label' e
+// campos set trazem 'labels' com os rótulos das opções.
+func groupCustomFields(record map[string]interface{}, fieldsMeta map[string]FieldMeta) map[string]interface{} {
+	customFields := make([]map[string]interface{}, 0)
+
+	for key, value := range record {
+		if meta, ok := fieldsMeta[key]; ok {
+			entry := map[string]interface{}{
+				"id":    meta.Key,
+				"name":  meta.Name,
+				"type":  meta.FieldType,
+				"value": value,
+			}
+			if hasOptions(meta) && !isBlank(value) {
+				labels := optionLabels(meta, value)
+				if meta.FieldType == fieldTypeEnum && len(labels) == 1 {
+					entry["label"] = labels[0]
+				} else {
+					entry["labels"] = labels
+				}
+			}
+			customFields = append(customFields, entry)
+			delete(record, key)
+		}
+	}
+
+	if len(customFields) > 0 {
+		record["custom_fields"] = customFields
+	}
+	return record
+}
+
+// detailTimeout é o tempo máximo da leitura de cada ID em ?id=
+const detailTimeout = 5 * time.Second
+
+// DefaultDetailConcurrency é o número padrão de leituras simultâneas em ?id=
+const DefaultDetailConcurrency = 8
+
+var (
+	detailConcurrencyMu sync.RWMutex
+	detailConcurrency   = DefaultDetailConcurrency
+)
+
+// SetDetailConcurrency define quantos IDs de ?id= são lidos ao mesmo tempo
+func SetDetailConcurrency(n int) {
+	if n <= 0 {
+		n = DefaultDetailConcurrency
+	}
+	detailConcurrencyMu.Lock()
+	detailConcurrency = n
+	detailConcurrencyMu.Unlock()
+}
+
+// DetailConcurrency retorna o limite atual de leituras simultâneas em ?id=
+func DetailConcurrency() int {
+	detailConcurrencyMu.RLock()
+	defer detailConcurrencyMu.RUnlock()
+	return detailConcurrency
+}
+
+// fetchDetail lê um registro para fetchMultipleDetails; a falha vem descrita
+// para o metadata
+func (res *Resource[T, C]) fetchDetail(ctx context.Context, c *client.PipedriveClient, id string, query url.Values) (map[string]interface{}, *utils.RateLimitInfo, *utils.ItemFailure) {
+	currentQuery := make(url.Values)
+	for k, v := range query {
+		currentQuery[k] = v
+	}
+
+	detailCtx, cancel := context.WithTimeout(ctx, detailTimeout)
+	defer cancel()
+
+	resp, body, rate, err := c.Do(detailCtx, utils.HTTPGet, fmt.Sprintf("%s/%s", res.Path, id), currentQuery)
+	if err != nil {
+		if errors.Is(detailCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, rate, &utils.ItemFailure{ID: id, Status: http.StatusGatewayTimeout, Error: fmt.Sprintf("timeout after %s", detailTimeout)}
+		}
+		return nil, rate, &utils.ItemFailure{ID: id, Status: http.StatusServiceUnavailable, Error: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	var pipedriveResponse struct {
+		Success bool                   `json:"success"`
+		Data    map[string]interface{} `json:"data"`
+		Error   interface{}            `json:"error"`
+	}
+	parseErr := json.Unmarshal(body, &pipedriveResponse)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, rate, &utils.ItemFailure{ID: id, Status: http.StatusNotFound, Error: fmt.Sprintf("%s %s not found", res.Singular, id)}
+	case resp.StatusCode != http.StatusOK:
+		var parsed map[string]interface{}
+		_ = json.Unmarshal(body, &parsed)
+		return nil, rate, &utils.ItemFailure{ID: id, Status: resp.StatusCode, Error: ExtractUpstreamError(parsed)}
+	case parseErr != nil:
+		return nil, rate, &utils.ItemFailure{ID: id, Status: http.StatusBadGateway, Error: fmt.Sprintf("failed to parse upstream response: %v", parseErr)}
+	case pipedriveResponse.Data == nil:
+		return nil, rate, &utils.ItemFailure{ID: id, Status: http.StatusNotFound, Error: fmt.Sprintf("%s %s not found", res.Singular, id)}
+	}
+	return pipedriveResponse.Data, rate, nil
+}
+
+// fieldsWarning descreve, para 'extra.warnings', a falha ao carregar os
+// campos: a leitura segue, mas os campos customizados ficam com a chave hash
+func (res *Resource[T, C]) fieldsWarning(err error) []string {
+	if err == nil {
+		return nil
+	}
+	return []string{fmt.Sprintf("custom fields were not grouped: cannot load %s fields: %v", res.Singular, err)}
+}
+
+// fetchMultipleDetails lê os IDs em paralelo (até DetailConcurrency por vez),
+// mantendo a ordem pedida. IDs que falham são omitidos de 'data' e
+// descritos em 'failures'.
+func (res *Resource[T, C]) fetchMultipleDetails(ctx context.Context, c *client.PipedriveClient, ids []string, query url.Values, fieldsMeta map[string]FieldMeta) ([]map[string]interface{}, []utils.ItemFailure, *utils.RateLimitInfo, int, error) {
+	wanted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			wanted = append(wanted, id)
+		}
+	}
+
+	records := make([]map[string]interface{}, len(wanted))
+	failed := make([]*utils.ItemFailure, len(wanted))
+	latestRate := &utils.RateLimitInfo{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, DetailConcurrency())
+
+	for i, id := range wanted {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			record, rate, failure := res.fetchDetail(ctx, c, id, query)
+			if rate != nil {
+				mu.Lock()
+				latestRate = rate
+				mu.Unlock()
+			}
+			if failure != nil {
+				failed[i] = failure
+				return
+			}
+			records[i] = groupCustomFields(record, fieldsMeta)
+		}(i, id)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, nil, latestRate, http.StatusGatewayTimeout, ctx.Err()
+	}
+
+	results := make([]map[string]interface{}, 0, len(wanted))
+	failures := make([]utils.ItemFailure, 0)
+	for i := range wanted {
+		if failed[i] != nil {
+			failures = append(failures, *failed[i])
+			continue
+		}
+		results = append(results, records[i])
+	}
+
+	if len(results) == 0 && len(wanted) > 0 {
+		return results, failures, latestRate, http.StatusNotFound, fmt.Errorf("no %s found for the provided IDs", res.Name)
+	}
+	return results, failures, latestRate, http.StatusOK, nil
+}
+
+func (res *Resource[T, C]) list(ctx context.Context, c *client.PipedriveClient, query url.Values, finalResponse *models.ListResponse[T]) (*utils.RateLimitInfo, int, error) {
+	finalResponse.Data = []T{}
+
+	rateLimitInfo := &utils.RateLimitInfo{}
+	upstreamStatus := http.StatusOK
+	limit := res.pageLimit()
+
+	pageFilter := query.Get("page")
+	query.Del("page")
+
+	isPageAll := pageFilter == "all"
+	start := 0
+
+	if pageFilter != "" && pageFilter != "all" {
+		page, err := strconv.Atoi(pageFilter)
+		if err == nil && page > 0 {
+			start = (page - 1) * limit
+		}
+	}
+
+	if query.Get("limit") == "" {
+		query.Set("limit", fmt.Sprintf("%d", limit))
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return rateLimitInfo, http.StatusGatewayTimeout, fmt.Errorf("gateway process cancelled: %w", ctx.Err())
+		}
+
+		currentQuery := make(url.Values)
+		for k, v := range query {
+			currentQuery[k] = v
+		}
+		currentQuery.Set("start", fmt.Sprintf("%d", start))
+
+		resp, body, rate, err := c.Do(ctx, utils.HTTPGet, res.Path, currentQuery)
+		if err != nil {
+			return rate, http.StatusServiceUnavailable, err
+		}
+
+		if rate != nil {
+			rateLimitInfo = rate
+		}
+		upstreamStatus = resp.StatusCode
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return rateLimitInfo, upstreamStatus, fmt.Errorf("upstream returned status: %s", resp.Status)
+		}
+
+		tempResponse := models.ListResponse[T]{}
+		if err := json.Unmarshal(body, &tempResponse); err != nil {
+			resp.Body.Close()
+			return rateLimitInfo, http.StatusInternalServerError, fmt.Errorf("failed to parse upstream response: %w", err)
+		}
+		resp.Body.Close()
+
+		finalResponse.Data = append(finalResponse.Data, tempResponse.Data...)
+
+		if !isPageAll || !tempResponse.AdditionalData.Pagination.MoreItemsInCollection {
+			break
+		}
+
+		start = tempResponse.AdditionalData.Pagination.NextStart
+	}
+
+	return rateLimitInfo, upstreamStatus, nil
+}
+
+// FetchAsMap busca um único registro e o devolve como mapa (campos customizados
+// permanecem com a chave hash original)
+func (res *Resource[T, C]) FetchAsMap(ctx context.Context, c *client.PipedriveClient, id int, q url.Values) (map[string]interface{}, int, error) {
+	if q == nil {
+		q = url.Values{}
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.Do(reqCtx, utils.HTTPGet, res.Path+"/"+strconv.Itoa(id), q)
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		return nil, status, wrapUpstreamErr("get", err, body)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var raw map[string]interface{}
+		_ = json.Unmarshal(body, &raw)
+		return raw, resp.StatusCode, fmt.Errorf("upstream status %s", resp.Status)
+	}
+
+	var parsed struct {
+		Success bool                   `json:"success"`
+		Data    map[string]interface{} `json:"data"`
+		Error   interface{}            `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("parse upstream response: %w", err)
+	}
+	return parsed.Data, http.StatusOK, nil
+}
+
+// HandleGet lista os registros (?page=N|all) ou, com ?id=1,2,3, retorna os
+// detalhes com os campos customizados agrupados em 'custom_fields'
+func (res *Resource[T, C]) HandleGet(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	id := query.Get("id")
+
+	fieldsQuery := query.Get("fields")
+	fieldsAll := strings.EqualFold(fieldsQuery, "all")
+	query.Del("fields")
+
+	if id != "" {
+		ids := strings.Split(id, ",")
+		query.Del("id")
+
+		c := client.NewPipedriveClient()
+		start := time.Now()
+
+		fieldsMeta, fieldsErr := res.FetchFields(r.Context(), c)
+		warnings := res.fieldsWarning(fieldsErr)
+		dataToReturn, failures, rate, upstreamStatus, err := res.fetchMultipleDetails(r.Context(), c, ids, query, fieldsMeta)
+
+		meta := res.withFieldCacheAge(utils.NewMetaItem(
+			start,
+			r.Header.Get(utils.HeaderXRequestID),
+			c.BaseURL()+fmt.Sprintf("%s/details/{%d IDs}", res.Path, len(ids)),
+			upstreamStatus,
+			rate,
+		))
+
+		if len(failures) > 0 || len(warnings) > 0 {
+			meta.Extra = &utils.ExtraMeta{Failures: failures, Warnings: warnings}
+		}
+
+		if err != nil {
+			meta.Status = upstreamStatus
+			utils.JSONError(w, upstreamStatus, err.Error(), meta)
+			return
+		}
+
+		if fieldsQuery != "" && !fieldsAll {
+			var filterErr error
+			dataToReturn, filterErr = utils.FilterMapSliceByFields(dataToReturn, fieldsQuery)
+			if filterErr != nil {
+				meta.Status = http.StatusInternalServerError
+				utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to filter fields: %v", filterErr), meta)
+				return
+			}
+		}
+
+		meta.Extra = &utils.ExtraMeta{TotalResults: len(dataToReturn), Failures: failures, Warnings: warnings}
+		utils.JSONOK(w, dataToReturn, meta)
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	start := time.Now()
+	envelope := &models.ListResponse[T]{}
+	rate, upstreamStatus, err := res.list(r.Context(), c, query, envelope)
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+res.Path,
+		upstreamStatus,
+		rate,
+	)
+
+	if err != nil {
+		meta.Status = upstreamStatus
+		utils.JSONError(w, upstreamStatus, err.Error(), meta)
+		return
+	}
+
+	if res.LocalFilter {
+		// Filtragem local (e.g., ?name=Maria&email=@empresa.com)
+		filtered, filterErr := utils.FilterSliceByQuery(envelope.GetDataSlice(), r.URL.Query())
+		if filterErr != nil {
+			meta.Status = http.StatusInternalServerError
+			utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply local filter: %v", filterErr), meta)
+			return
+		}
+		envelope.SetDataSlice(filtered)
+	}
+
+	meta.Extra = &utils.ExtraMeta{TotalResults: len(envelope.Data)}
+	utils.JSONOK(w, envelope, meta)
+}
@@ -0,0 +1,173 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/utils"
+)
+
+// Handlers de /pipedrive/<recurso>/{id}. Diferente das rotas em massa, um id
+// inexistente responde 404 com o envelope padrão.
+
+func (res *Resource[T, C]) pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		utils.JSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s id '%s'", res.Singular, r.PathValue("id")), nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func (res *Resource[T, C]) itemMeta(r *http.Request, c *client.PipedriveClient, start time.Time, id int, status int) *utils.MetaItem {
+	return utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+fmt.Sprintf("%s/%d", res.Path, id),
+		status,
+		nil,
+	)
+}
+
+// writeItemError responde o erro de uma operação de item, convertendo
+// 404/410 do Pipedrive em "<recurso> {id} not found"
+func (res *Resource[T, C]) writeItemError(w http.ResponseWriter, meta *utils.MetaItem, id int, status int, err error) {
+	meta.Status = status
+	if status == http.StatusNotFound || status == http.StatusGone {
+		meta.Status = http.StatusNotFound
+		utils.JSONError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", res.Singular, id), meta)
+		return
+	}
+	utils.JSONError(w, status, err.Error(), meta)
+}
+
+// HandleGetByID retorna um registro com os campos customizados agrupados em
+// 'custom_fields' (aceita ?fields=a,b)
+func (res *Resource[T, C]) HandleGetByID(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	id, ok := res.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	fieldsQuery := query.Get("fields")
+	query.Del("fields")
+
+	c := client.NewPipedriveClient()
+	record, status, err := res.FetchAsMap(r.Context(), c, id, query)
+	meta := res.itemMeta(r, c, start, id, status)
+
+	if err == nil && record == nil {
+		status, err = http.StatusNotFound, fmt.Errorf("%s %d not found", res.Singular, id)
+	}
+	if err != nil {
+		res.writeItemError(w, meta, id, status, err)
+		return
+	}
+
+	fieldsMeta, fieldsErr := res.FetchFields(r.Context(), c)
+	record = groupCustomFields(record, fieldsMeta)
+	res.withFieldCacheAge(meta)
+	if warnings := res.fieldsWarning(fieldsErr); len(warnings) > 0 {
+		meta.Extra = &utils.ExtraMeta{Warnings: warnings}
+	}
+
+	if fieldsQuery != "" && !strings.EqualFold(fieldsQuery, "all") {
+		filtered, filterErr := utils.FilterMapSliceByFields([]map[string]interface{}{record}, fieldsQuery)
+		if filterErr != nil {
+			meta.Status = http.StatusInternalServerError
+			utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to filter fields: %v", filterErr), meta)
+			return
+		}
+		record = filtered[0]
+	}
+
+	utils.JSONOK(w, record, meta)
+}
+
+// HandlePutByID aplica um item de atualização ({type, verbose, fields}) ao
+// registro do caminho
+func (res *Resource[T, C]) HandlePutByID(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	id, ok := res.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var it UpdateItem
+	if err := json.NewDecoder(r.Body).Decode(&it); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid JSON body", nil)
+		return
+	}
+	if it.ID != 0 && it.ID != id {
+		utils.JSONError(w, http.StatusBadRequest, fmt.Sprintf("body id %d does not match path id %d", it.ID, id), nil)
+		return
+	}
+	it.ID = id
+
+	res.writeUpdate(w, r, start, it)
+}
+
+// HandlePatchByID atualiza apenas os campos enviados no corpo (objeto plano,
+// como cada item do PATCH em massa). Com application/merge-patch+json ou
+// application/json-patch+json o patch é aplicado sobre o registro atual.
+// ?verbose=true devolve o registro atualizado e ?verbose=diff os campos
+// alterados com os valores antigo e novo.
+func (res *Resource[T, C]) HandlePatchByID(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	id, ok := res.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	res.patchByID(w, r, start, id, patchMediaType(r))
+}
+
+func (res *Resource[T, C]) writeUpdate(w http.ResponseWriter, r *http.Request, start time.Time, it UpdateItem) {
+	c := client.NewPipedriveClient()
+	result, status, err := res.applyUpdate(r.Context(), c, it)
+	meta := res.withFieldCacheAge(res.itemMeta(r, c, start, it.ID, status))
+
+	if err != nil {
+		res.writeItemError(w, meta, it.ID, status, err)
+		return
+	}
+	utils.JSONOK(w, result, meta)
+}
+
+// HandleDeleteByID remove o registro do caminho
+func (res *Resource[T, C]) HandleDeleteByID(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	id, ok := res.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	status, detail, err := res.deleteOne(r.Context(), c, id)
+	meta := res.itemMeta(r, c, start, id, status)
+
+	if err != nil {
+		if status == http.StatusNotFound || status == http.StatusGone || detail == nil {
+			res.writeItemError(w, meta, id, status, err)
+			return
+		}
+		meta.Status = status
+		utils.JSONError(w, status, map[string]interface{}{
+			"message": err.Error(),
+			"detail":  detail,
+		}, meta)
+		return
+	}
+
+	utils.JSONOK(w, map[string]interface{}{
+		"success": true,
+		"deleted": id,
+	}, meta)
+}
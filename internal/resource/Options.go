@@ -0,0 +1,136 @@
+package resource
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Campos enum/set guardam IDs de opção. Na leitura, o rótulo é acrescentado
+// ao lado do valor; na escrita, rótulos são trocados pelos IDs.
+
+type FieldOption struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+}
+
+const (
+	fieldTypeEnum = "enum"
+	fieldTypeSet  = "set"
+)
+
+func hasOptions(meta FieldMeta) bool {
+	return (meta.FieldType == fieldTypeEnum || meta.FieldType == fieldTypeSet) && len(meta.Options) > 0
+}
+
+// optionValues separa o valor de um enum/set ("37", 37, "37,38" ou [37, 38]);
+// o texto de um enum não é separado, pois o rótulo pode conter vírgulas
+func optionValues(meta FieldMeta, value interface{}) []interface{} {
+	if _, ok := value.(string); ok && meta.FieldType == fieldTypeEnum {
+		return []interface{}{value}
+	}
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	case string:
+		parts := strings.Split(v, ",")
+		out := make([]interface{}, 0, len(parts))
+		for _, p := range parts {
+			if p = strings.TrimSpace(p); p != "" {
+				out = append(out, p)
+			}
+		}
+		return out
+	default:
+		return []interface{}{v}
+	}
+}
+
+// optionLabels retorna o rótulo de cada ID; IDs sem opção ficam como estão
+func optionLabels(meta FieldMeta, value interface{}) []string {
+	labels := make([]string, 0)
+	for _, v := range optionValues(meta, value) {
+		label := fmt.Sprint(v)
+		for _, opt := range meta.Options {
+			if strconv.Itoa(opt.ID) == label {
+				label = opt.Label
+				break
+			}
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// optionID resolve um ID ou rótulo (sem diferenciar maiúsculas) de uma opção
+func optionID(meta FieldMeta, ref interface{}) (int, error) {
+	text := strings.TrimSpace(fmt.Sprint(ref))
+	for _, opt := range meta.Options {
+		if strconv.Itoa(opt.ID) == text {
+			return opt.ID, nil
+		}
+	}
+
+	matches := make([]int, 0, 1)
+	for _, opt := range meta.Options {
+		if strings.EqualFold(strings.TrimSpace(opt.Label), text) {
+			matches = append(matches, opt.ID)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		labels := make([]string, 0, len(meta.Options))
+		for _, opt := range meta.Options {
+			labels = append(labels, opt.Label)
+		}
+		return 0, fmt.Errorf("invalid option '%s' for field '%s': use one of %s", text, meta.Name, strings.Join(labels, ", "))
+	default:
+		return 0, fmt.Errorf("ambiguous option '%s' for field '%s': use the option id", text, meta.Name)
+	}
+}
+
+// resolveOptionLabels troca rótulos por IDs nos campos enum/set. Um set
+// enviado como lista continua lista; como texto, IDs separados por vírgula.
+func resolveOptionLabels(fieldsMeta map[string]FieldMeta, fields map[string]interface{}) error {
+	for key, value := range fields {
+		meta, ok := fieldsMeta[key]
+		if !ok || !hasOptions(meta) || isBlank(value) {
+			continue
+		}
+
+		values := optionValues(meta, value)
+		if meta.FieldType == fieldTypeEnum && len(values) > 1 {
+			return fmt.Errorf("field '%s' accepts a single option", meta.Name)
+		}
+
+		ids := make([]interface{}, 0, len(values))
+		texts := make([]string, 0, len(values))
+		for _, v := range values {
+			id, err := optionID(meta, v)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+			texts = append(texts, strconv.Itoa(id))
+		}
+
+		switch {
+		case meta.FieldType == fieldTypeEnum:
+			fields[key] = ids[0]
+		case isList(value):
+			fields[key] = ids
+		default:
+			fields[key] = strings.Join(texts, ",")
+		}
+	}
+	return nil
+}
+
+func isList(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok
+}
@@ -0,0 +1,294 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/utils"
+)
+
+// PatchItem é um item do corpo do PATCH em massa. 'patch' segue o
+// Content-Type da requisição: objeto (campos ou merge patch) ou lista de
+// operações (JSON Patch).
+type PatchItem struct {
+	ID      int             `json:"id"`
+	Verbose Verbosity       `json:"verbose,omitempty"`
+	Patch   json.RawMessage `json:"patch"`
+}
+
+// patchMediaType retorna o formato do patch; qualquer Content-Type que não
+// seja merge patch ou JSON Patch é tratado como application/json
+func patchMediaType(r *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(utils.HeaderContentType))
+	if err == nil && (mediaType == utils.ContentTypeMergePatch || mediaType == utils.ContentTypeJSONPatch) {
+		return mediaType
+	}
+	return utils.ContentTypeJSON
+}
+
+// referenceFieldTypes são os tipos cujo valor o GET expande em objeto
+// (e.g. org_id: {value, name, ...}), mas o PUT aceita apenas o ID
+var referenceFieldTypes = map[string]bool{
+	"user":   true,
+	"org":    true,
+	"people": true,
+}
+
+// referenceID reduz o objeto expandido de uma referência ao seu ID ('value'
+// ou 'id'); outros valores seguem como estão
+func referenceID(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	if id, ok := m["value"]; ok {
+		return id
+	}
+	if id, ok := m["id"]; ok {
+		return id
+	}
+	return v
+}
+
+// reduceReferences troca, em cada registro, os campos de referência pelo ID
+func (res *Resource[T, C]) reduceReferences(ctx context.Context, c *client.PipedriveClient, records ...map[string]interface{}) error {
+	fieldsMeta, err := res.FetchFields(ctx, c)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s reference fields: %w", res.Singular, err)
+	}
+	for key, meta := range fieldsMeta {
+		if !referenceFieldTypes[meta.FieldType] {
+			continue
+		}
+		for _, record := range records {
+			if v, ok := record[key]; ok {
+				record[key] = referenceID(v)
+			}
+		}
+	}
+	return nil
+}
+
+// computePatch busca o registro atual, aplica o patch e retorna apenas os
+// campos de primeiro nível que mudaram (o corpo enviado ao PUT do Pipedrive).
+// Referências são comparadas pelo ID: um patch em /org_id/value envia só o
+// novo ID, e mudanças nas demais chaves do objeto expandido são ignoradas.
+func (res *Resource[T, C]) computePatch(ctx context.Context, c *client.PipedriveClient, id int, mediaType string, raw []byte) (map[string]interface{}, int, error) {
+	var patched interface{}
+
+	current, status, err := res.FetchAsMap(ctx, c, id, nil)
+	if err != nil {
+		return nil, status, fmt.Errorf("fetch before patch: %w", err)
+	}
+	if current == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("%s %d not found", res.Singular, id)
+	}
+
+	switch mediaType {
+	case utils.ContentTypeMergePatch:
+		var patch interface{}
+		if err := json.Unmarshal(raw, &patch); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid merge patch: %w", err)
+		}
+		if _, ok := patch.(map[string]interface{}); !ok {
+			return nil, http.StatusBadRequest, errors.New("merge patch must be a JSON object")
+		}
+		patched = utils.MergePatch(current, patch)
+
+	case utils.ContentTypeJSONPatch:
+		var ops []utils.JSONPatchOp
+		if err := json.Unmarshal(raw, &ops); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid JSON patch: %w", err)
+		}
+		patched, err = utils.ApplyJSONPatch(current, ops)
+		if err != nil {
+			return nil, http.StatusUnprocessableEntity, err
+		}
+
+	default:
+		return nil, http.StatusUnsupportedMediaType, fmt.Errorf("unsupported patch media type '%s'", mediaType)
+	}
+
+	after, ok := patched.(map[string]interface{})
+	if !ok {
+		return nil, http.StatusUnprocessableEntity, fmt.Errorf("patched %s must remain a JSON object", res.Singular)
+	}
+	if err := res.reduceReferences(ctx, c, current, after); err != nil {
+		return nil, http.StatusServiceUnavailable, err
+	}
+	return utils.TopLevelChanges(current, after), http.StatusOK, nil
+}
+
+// patchUpdate converte o patch de um item na atualização enviada ao PUT do
+// Pipedrive. Em application/json o objeto é o próprio conjunto de campos
+// (null grava null), como no PUT; nos formatos de patch entram só os campos
+// que mudaram no registro atual. 'changed' é falso quando o patch não tem efeito.
+func (res *Resource[T, C]) patchUpdate(ctx context.Context, c *client.PipedriveClient, mediaType string, it PatchItem) (UpdateItem, bool, int, error) {
+	update := UpdateItem{
+		Type:    string(updateReplace),
+		Verbose: it.Verbose,
+		ID:      it.ID,
+	}
+	if it.ID <= 0 {
+		return update, false, http.StatusBadRequest, errors.New("invalid id")
+	}
+
+	if mediaType == utils.ContentTypeJSON {
+		if err := json.Unmarshal(it.Patch, &update.Fields); err != nil || update.Fields == nil {
+			return update, false, http.StatusBadRequest, errors.New("patch must be a JSON object")
+		}
+		return update, true, http.StatusOK, nil
+	}
+
+	fields, status, err := res.computePatch(ctx, c, it.ID, mediaType, it.Patch)
+	if err != nil {
+		return update, false, status, err
+	}
+	update.Fields = fields
+	return update, len(fields) > 0, http.StatusOK, nil
+}
+
+// applyPatch calcula e envia o patch de um registro. Um patch sem efeito
+// não gera chamada de escrita.
+func (res *Resource[T, C]) applyPatch(ctx context.Context, c *client.PipedriveClient, mediaType string, it PatchItem) (interface{}, int, error) {
+	update, changed, status, err := res.patchUpdate(ctx, c, mediaType, it)
+	if err != nil {
+		return nil, status, err
+	}
+	if !changed {
+		return map[string]interface{}{
+			"success":        true,
+			"fields_altered": []string{},
+		}, http.StatusOK, nil
+	}
+	return res.applyUpdate(ctx, c, update)
+}
+
+// previewPatch mostra o antes/depois de um patch, sem gravar
+func (res *Resource[T, C]) previewPatch(ctx context.Context, c *client.PipedriveClient, mediaType string, it PatchItem) (interface{}, int, error) {
+	update, changed, status, err := res.patchUpdate(ctx, c, mediaType, it)
+	if err != nil {
+		return nil, status, err
+	}
+	if !changed {
+		return map[string]interface{}{
+			"action":    "update",
+			"id":        it.ID,
+			"changes":   map[string]interface{}{},
+			"unchanged": []string{},
+		}, http.StatusOK, nil
+	}
+	return res.previewUpdate(ctx, c, update)
+}
+
+// HandlePatch aplica patches em massa ([{id, patch, verbose}]) conforme o
+// Content-Type: campos diretos (application/json), merge patch
+// (application/merge-patch+json) ou JSON Patch (application/json-patch+json).
+// Aceita ?dry_run=true e ?async=true, como o PUT em massa.
+func (res *Resource[T, C]) HandlePatch(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	mediaType := patchMediaType(r)
+
+	var items []PatchItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "invalid JSON body",
+			"hint":    "body must be a list of {id, patch}",
+			"example_bulk": []map[string]interface{}{
+				{"id": 1, "patch": map[string]interface{}{"name": "Novo nome"}},
+			},
+		}, nil)
+		return
+	}
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, "empty patch list", nil)
+		return
+	}
+
+	concurrency, err := bulkConcurrency(r)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	dryRun := res.dryRun(r)
+	apply := res.applyPatch
+	if dryRun {
+		apply = res.previewPatch
+	}
+
+	// Itens com o mesmo ID rodam em sequência, na ordem do corpo
+	keys := make([]string, len(items))
+	for i, it := range items {
+		keys[i] = strconv.Itoa(it.ID)
+	}
+
+	url := c.BaseURL() + res.Path + dryRunOperation(" (patch bulk)", dryRun)
+	bulk := runBulk(w, r, start, url, keys, func(ctx context.Context, bulk *bulkRun) {
+		bulk.run(ctx, concurrency, keys, keys, func(ctx context.Context, i int) {
+			result, status, err := apply(ctx, c, mediaType, items[i])
+			if err != nil {
+				bulk.fail(keys[i], status, err)
+				return
+			}
+			bulk.set(keys[i], result, true)
+		})
+	})
+	if bulk == nil {
+		return
+	}
+
+	meta := res.withFieldCacheAge(utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		url,
+		http.StatusMultiStatus,
+		nil,
+	))
+
+	utils.JSONOK(w, models.BulkUpdateResult{
+		Status:  BulkStatus(bulk.success, len(items)),
+		DryRun:  dryRun,
+		Results: bulk.results,
+	}, meta)
+}
+
+// patchByID trata PATCH /{id} no formato do Content-Type
+func (res *Resource[T, C]) patchByID(w http.ResponseWriter, r *http.Request, start time.Time, id int, mediaType string) {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "failed to read request body", nil)
+		return
+	}
+
+	verbose, err := ParseVerbosity(r.URL.Query().Get("verbose"))
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	result, status, err := res.applyPatch(r.Context(), c, mediaType, PatchItem{
+		ID:      id,
+		Verbose: verbose,
+		Patch:   raw,
+	})
+	meta := res.withFieldCacheAge(res.itemMeta(r, c, start, id, status))
+
+	if err != nil {
+		res.writeItemError(w, meta, id, status, err)
+		return
+	}
+	utils.JSONOK(w, result, meta)
+}
@@ -0,0 +1,315 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/users"
+	"pipedrive_api_service/internal/utils"
+)
+
+// createPayload converte o item de criação no corpo enviado ao Pipedrive;
+// 'custom_fields' é achatado depois, por resolveCustomFields
+func createPayload(item interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func isBlank(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	s, ok := v.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+// HandlePost cria um ou vários registros; cada item é enviado individualmente,
+// em paralelo (até ?concurrency=).
+// Com ?upsert=<campo>, cada item atualiza o registro com o mesmo valor no
+// campo (ou é criado quando não há nenhum). Com ?dry_run=true, retorna o
+// payload de cada criação (e o antes/depois de cada upsert) sem gravar; com
+// ?async=true, executa os itens em um job.
+func (res *Resource[T, C]) HandlePost(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	operation := " (create bulk)"
+	upsertKey := strings.TrimSpace(r.URL.Query().Get("upsert"))
+	if upsertKey != "" {
+		resolved, status, err := res.resolveUpsertKey(r.Context(), c, upsertKey)
+		if err != nil {
+			utils.JSONError(w, status, err.Error(), nil)
+			return
+		}
+		upsertKey = resolved
+		if _, err := res.upsertSearchField(upsertKey); err != nil {
+			utils.JSONError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		operation = " (upsert bulk)"
+	}
+	dryRun := res.dryRun(r)
+
+	bodyRaw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "failed to read request body",
+			"detail":  err.Error(),
+		}, nil)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(bodyRaw) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "request body is empty",
+			"hint":    fmt.Sprintf("provide at least one %s to create", res.Singular),
+		}, nil)
+		return
+	}
+
+	var items []C
+
+	if err := json.Unmarshal(bodyRaw, &items); err != nil {
+		var single C
+		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil && res.hasPrimaryField(single) {
+			items = append(items, single)
+		} else {
+			utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+				"message":        "invalid JSON format",
+				"hint":           "body must be either a single object or an array of objects",
+				"example_single": res.ExampleSingle,
+				"example_bulk":   res.ExampleBulk,
+			}, nil)
+			return
+		}
+	}
+
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": fmt.Sprintf("no valid %s found in body", res.Name),
+			"hint":    "check your JSON structure and required fields",
+		}, nil)
+		return
+	}
+
+	concurrency, err := bulkConcurrency(r)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	url := c.BaseURL() + res.Path + dryRunOperation(operation, dryRun)
+	bulk := runBulk(w, r, start, url, indexKeys(len(items)), func(ctx context.Context, bulk *bulkRun) {
+		// Validação local primeiro; só os itens válidos vão para a execução em
+		// paralelo. Com upsert, itens com a mesma chave rodam em sequência e
+		// compartilham 'seen' (ou 'planned', no dry run).
+		keys := make([]string, 0, len(items))
+		lanes := make([]string, 0, len(items))
+		payloads := make([]map[string]interface{}, 0, len(items))
+		seen := map[string]map[string]int{}
+		planned := map[string]map[string]bool{}
+
+		for i, item := range items {
+			indexKey := fmt.Sprintf("%d", i)
+
+			payload, err := createPayload(item)
+			if err != nil {
+				bulk.fail(indexKey, http.StatusBadRequest, fmt.Errorf("invalid item: %v", err))
+				continue
+			}
+
+			if err := res.resolveCustomFields(ctx, c, payload); err != nil {
+				bulk.fail(indexKey, http.StatusBadRequest, err)
+				continue
+			}
+
+			if missing := res.missingRequired(payload); missing != "" {
+				bulk.fail(indexKey, http.StatusBadRequest, fmt.Errorf("field '%s' is required", missing))
+				continue
+			}
+
+			if status, err := res.validateFieldTypes(ctx, c, payload, nil); err != nil {
+				bulk.fail(indexKey, status, err)
+				continue
+			}
+
+			lane := indexKey
+			if value, ok := payload[upsertKey]; upsertKey != "" && ok && !isBlank(value) {
+				lane = "upsert:" + strings.ToLower(strings.TrimSpace(fmt.Sprint(value)))
+			}
+			if _, ok := seen[lane]; !ok {
+				seen[lane] = map[string]int{}
+				planned[lane] = map[string]bool{}
+			}
+
+			keys = append(keys, indexKey)
+			lanes = append(lanes, lane)
+			payloads = append(payloads, payload)
+		}
+
+		bulk.run(ctx, concurrency, keys, lanes, func(ctx context.Context, i int) {
+			indexKey, payload := keys[i], payloads[i]
+
+			// Com upsert, 'owner_email' é resolvido em upsertOne: na criação vira
+			// o dono, na atualização segue as regras do PUT
+			if upsertKey != "" {
+				var result interface{}
+				var status int
+				var err error
+				if dryRun {
+					result, status, err = res.previewUpsert(ctx, c, upsertKey, payload, planned[lanes[i]])
+				} else {
+					result, status, err = res.upsertOne(ctx, c, upsertKey, payload, seen[lanes[i]])
+				}
+				if err != nil {
+					failure := map[string]interface{}{
+						"error":  err.Error(),
+						"status": status,
+					}
+					if extra, ok := result.(map[string]interface{}); ok {
+						for k, v := range extra {
+							failure[k] = v
+						}
+					}
+					bulk.set(indexKey, failure, false)
+					return
+				}
+				bulk.set(indexKey, result, true)
+				return
+			}
+
+			if ownerErr := res.resolveCreateOwner(ctx, c, payload); ownerErr != nil {
+				bulk.fail(indexKey, http.StatusBadRequest, ownerErr)
+				return
+			}
+
+			if dryRun {
+				bulk.set(indexKey, previewCreate(payload), true)
+				return
+			}
+
+			data, status, err := res.createOne(ctx, c, payload)
+			if errors.Is(err, errMissingData) {
+				bulk.set(indexKey, map[string]interface{}{
+					"warning": "upstream response missing 'data' field",
+					"status":  status,
+				}, false)
+				return
+			}
+			if err != nil {
+				bulk.fail(indexKey, status, err)
+				return
+			}
+			bulk.set(indexKey, data, true)
+		})
+	})
+	if bulk == nil {
+		return
+	}
+
+	meta := res.withFieldCacheAge(utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		url,
+		http.StatusCreated,
+		nil,
+	))
+
+	data := map[string]interface{}{
+		"status":  BulkStatus(bulk.success, len(items)),
+		"results": bulk.results,
+	}
+	if dryRun {
+		data["dry_run"] = true
+	}
+	utils.JSONOK(w, data, meta)
+}
+
+// hasPrimaryField indica se um objeto único traz o primeiro campo obrigatório
+func (res *Resource[T, C]) hasPrimaryField(item C) bool {
+	if len(res.Required) == 0 {
+		return true
+	}
+	payload, err := createPayload(item)
+	if err != nil {
+		return false
+	}
+	return !isBlank(payload[res.Required[0]])
+}
+
+func (res *Resource[T, C]) missingRequired(payload map[string]interface{}) string {
+	for _, key := range res.Required {
+		if isBlank(payload[key]) {
+			return key
+		}
+	}
+	return ""
+}
+
+// resolveCreateOwner troca 'owner_email' pelo ID do dono em OwnerField
+func (res *Resource[T, C]) resolveCreateOwner(ctx context.Context, c *client.PipedriveClient, payload map[string]interface{}) error {
+	raw, ok := payload["owner_email"]
+	if !ok {
+		return nil
+	}
+	delete(payload, "owner_email")
+	if res.OwnerField == "" || isBlank(raw) {
+		return nil
+	}
+	if _, ok := payload[res.OwnerField]; ok {
+		return fmt.Errorf("use either '%s' or 'owner_email', not both", res.OwnerField)
+	}
+	ownerID, err := users.ResolveOwnerEmail(ctx, c, fmt.Sprint(raw))
+	if err != nil {
+		return err
+	}
+	payload[res.OwnerField] = ownerID
+	return nil
+}
+
+var errMissingData = errors.New("upstream response missing 'data' field")
+
+// createOne envia o payload de criação e retorna o 'data' do Pipedrive
+func (res *Resource[T, C]) createOne(ctx context.Context, c *client.PipedriveClient, payload map[string]interface{}) (interface{}, int, error) {
+	bodyBytes, _ := json.Marshal(payload)
+
+	reqCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPost, res.Path, nil, bytes.NewReader(bodyBytes))
+	if err != nil || resp == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("failed to reach upstream Pipedrive: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, resp.StatusCode, fmt.Errorf("upstream returned %d", resp.StatusCode)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("unable to parse upstream response: %v", err)
+	}
+
+	data, ok := parsed["data"]
+	if !ok {
+		return nil, resp.StatusCode, errMissingData
+	}
+	return data, resp.StatusCode, nil
+}
@@ -0,0 +1,327 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/users"
+	"pipedrive_api_service/internal/utils"
+)
+
+type updateType string
+
+const (
+	updateReplace updateType = "replace"
+	updateAdd     updateType = "add"
+	updateRemove  updateType = "remove"
+)
+
+// UpdateItem é um item do corpo do PUT em massa
+type UpdateItem struct {
+	Type    string                 `json:"type"`
+	Verbose Verbosity              `json:"verbose,omitempty"`
+	ID      int                    `json:"id"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// HandlePut aplica atualizações em massa nos modos replace, add e remove.
+// Com ?dry_run=true, retorna o antes/depois de cada item sem gravar; com
+// ?async=true, executa os itens em um job.
+func (res *Resource[T, C]) HandlePut(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	var items []UpdateItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid JSON body", nil)
+		return
+	}
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, "empty update list", nil)
+		return
+	}
+
+	concurrency, err := bulkConcurrency(r)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	dryRun := res.dryRun(r)
+	apply := res.applyUpdate
+	if dryRun {
+		apply = res.previewUpdate
+	}
+
+	// Itens com o mesmo ID rodam em sequência, na ordem do corpo
+	keys := make([]string, len(items))
+	for i, it := range items {
+		keys[i] = strconv.Itoa(it.ID)
+	}
+
+	url := c.BaseURL() + res.Path + dryRunOperation(" (bulk)", dryRun)
+	bulk := runBulk(w, r, start, url, keys, func(ctx context.Context, bulk *bulkRun) {
+		bulk.run(ctx, concurrency, keys, keys, func(ctx context.Context, i int) {
+			result, status, err := apply(ctx, c, items[i])
+			if err != nil {
+				bulk.fail(keys[i], status, err)
+				return
+			}
+			bulk.set(keys[i], result, true)
+		})
+	})
+	if bulk == nil {
+		return
+	}
+
+	meta := res.withFieldCacheAge(utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		url,
+		http.StatusMultiStatus,
+		nil,
+	))
+
+	resp := models.BulkUpdateResult{
+		Status:  BulkStatus(bulk.success, len(items)),
+		DryRun:  dryRun,
+		Results: bulk.results,
+	}
+	utils.JSONOK(w, resp, meta)
+}
+
+// --- Helpers ---
+
+// applyUpdate valida e executa um item de atualização (replace, add ou remove)
+func (res *Resource[T, C]) applyUpdate(ctx context.Context, c *client.PipedriveClient, it UpdateItem) (interface{}, int, error) {
+	fields, status, err := res.prepareUpdate(ctx, c, it)
+	if err != nil {
+		return nil, status, err
+	}
+	return res.doReplace(ctx, c, it.ID, fields, it.Verbose)
+}
+
+// prepareUpdate valida o item e monta o corpo enviado ao PUT do Pipedrive;
+// não faz chamadas de escrita (usado também pelo dry run)
+func (res *Resource[T, C]) prepareUpdate(ctx context.Context, c *client.PipedriveClient, it UpdateItem) (map[string]interface{}, int, error) {
+	if it.ID <= 0 {
+		return nil, http.StatusBadRequest, errors.New("invalid id")
+	}
+
+	if err := res.resolveCustomFields(ctx, c, it.Fields); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	op := normalizeType(it.Type)
+
+	if _, ok := it.Fields["owner_email"]; ok && res.OwnerField != "" && op != updateReplace {
+		return nil, http.StatusBadRequest, errors.New("field 'owner_email' is only supported with type 'replace'")
+	}
+
+	switch op {
+	case updateReplace:
+		if len(it.Fields) == 0 {
+			return nil, http.StatusBadRequest, errors.New("fields required for replace")
+		}
+		if status, err := res.validateFieldTypes(ctx, c, it.Fields, res.ReadOnly); err != nil {
+			return nil, status, err
+		}
+		if err := res.resolveOwnerEmail(ctx, c, it.Fields); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		return it.Fields, http.StatusOK, nil
+
+	case updateAdd:
+		if len(it.Fields) == 0 {
+			return nil, http.StatusBadRequest, errors.New("fields required for add")
+		}
+		if status, err := res.validateFieldTypes(ctx, c, it.Fields, res.ReadOnly); err != nil {
+			return nil, status, err
+		}
+		return res.addFields(ctx, c, it.ID, it.Fields)
+
+	case updateRemove:
+		if len(it.Fields) == 0 {
+			return nil, http.StatusBadRequest, errors.New("fields (keys) required for remove")
+		}
+		return removeFields(it.Fields), http.StatusOK, nil
+
+	default:
+		return nil, http.StatusBadRequest, errors.New("unsupported type")
+	}
+}
+
+// doReplace envia os campos ao PUT do Pipedrive. Com VerbosityDiff, o
+// registro é lido antes para calcular {campo: {old, new}}.
+func (res *Resource[T, C]) doReplace(ctx context.Context, c *client.PipedriveClient, id int, fields map[string]interface{}, verbose Verbosity) (interface{}, int, error) {
+	bodyBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("marshal fields: %w", err)
+	}
+
+	var before map[string]interface{}
+	if verbose == VerbosityDiff {
+		current, status, err := res.fetchExisting(ctx, c, id)
+		if err != nil {
+			return nil, status, fmt.Errorf("fetch before update: %w", err)
+		}
+		before = current
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPut, res.Path+"/"+strconv.Itoa(id), nil, bytes.NewReader(bodyBytes))
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		return nil, status, wrapUpstreamErr("replace", err, body)
+	}
+	defer resp.Body.Close()
+
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+
+	if resp.StatusCode >= 400 {
+		detail := ExtractUpstreamError(parsed)
+		return parsed, resp.StatusCode, errors.New(detail)
+	}
+
+	switch verbose {
+	case VerbosityFull:
+		return parsed["data"], resp.StatusCode, nil
+	case VerbosityDiff:
+		after, _ := parsed["data"].(map[string]interface{})
+		return map[string]interface{}{
+			"success": true,
+			"diff":    res.fieldDiff(ctx, c, before, after, fields),
+		}, resp.StatusCode, nil
+	}
+	return map[string]interface{}{
+		"success":        true,
+		"fields_altered": extractFieldKeys(fields),
+	}, resp.StatusCode, nil
+}
+
+// addFields concatena os valores aos atuais (listas em ListFields recebem
+// novos itens)
+func (res *Resource[T, C]) addFields(ctx context.Context, c *client.PipedriveClient, id int, additions map[string]interface{}) (map[string]interface{}, int, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	current, status, err := res.FetchAsMap(reqCtx, c, id, nil)
+	if err != nil {
+		return nil, status, fmt.Errorf("fetch before add: %w", err)
+	}
+
+	update := make(map[string]interface{}, len(additions))
+	for k, v := range additions {
+		if res.ListFields[k] {
+			update[k] = appendContacts(current[k], v)
+			continue
+		}
+		old := fmt.Sprint(current[k])
+		newVal := strings.TrimSpace(strings.Join([]string{old, fmt.Sprint(v)}, " "))
+		newVal = strings.TrimSpace(strings.ReplaceAll(newVal, "  ", " "))
+		update[k] = newVal
+	}
+	return update, http.StatusOK, nil
+}
+
+func removeFields(fields map[string]interface{}) map[string]interface{} {
+	clear := make(map[string]interface{}, len(fields))
+	for k := range fields {
+		clear[k] = ""
+	}
+	return clear
+}
+
+// resolveOwnerEmail troca 'owner_email' pelo ID do usuário em OwnerField.
+// Roda depois de validateFieldTypes: o dono só pode ser alterado via email.
+func (res *Resource[T, C]) resolveOwnerEmail(ctx context.Context, c *client.PipedriveClient, fields map[string]interface{}) error {
+	if res.OwnerField == "" {
+		return nil
+	}
+	raw, ok := fields["owner_email"]
+	if !ok {
+		return nil
+	}
+	ownerID, err := users.ResolveOwnerEmail(ctx, c, fmt.Sprint(raw))
+	if err != nil {
+		return err
+	}
+	delete(fields, "owner_email")
+	fields[res.OwnerField] = ownerID
+	return nil
+}
+
+func normalizeType(t string) updateType {
+	switch strings.ToLower(strings.TrimSpace(t)) {
+	case "", "replace":
+		return updateReplace
+	case "add":
+		return updateAdd
+	case "remove":
+		return updateRemove
+	default:
+		return updateType("unsupported")
+	}
+}
+
+func wrapUpstreamErr(op string, err error, body []byte) error {
+	if len(body) == 0 {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return fmt.Errorf("%s: %w; upstream=%s", op, err, string(body))
+}
+
+func extractFieldKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// appendContacts soma novos emails/telefones à lista existente, aceitando
+// tanto strings simples quanto objetos {value, primary, label}.
+func appendContacts(current interface{}, additions interface{}) []interface{} {
+	out := make([]interface{}, 0)
+	if list, ok := current.([]interface{}); ok {
+		for _, entry := range list {
+			if m, ok := entry.(map[string]interface{}); ok && fmt.Sprint(m["value"]) == "" {
+				continue
+			}
+			out = append(out, entry)
+		}
+	}
+
+	switch v := additions.(type) {
+	case []interface{}:
+		for _, entry := range v {
+			out = append(out, normalizeContact(entry))
+		}
+	case nil:
+	default:
+		out = append(out, normalizeContact(v))
+	}
+	return out
+}
+
+func normalizeContact(entry interface{}) interface{} {
+	if str, ok := entry.(string); ok {
+		return map[string]interface{}{"value": str, "primary": false}
+	}
+	return entry
+}
@@ -0,0 +1,106 @@
+// Package resource gera os handlers de listagem, detalhe, criação, atualização
+// e remoção em massa a partir de uma definição declarativa de recurso do
+// Pipedrive (deals, organizações, pessoas, produtos, ...).
+package resource
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimeout é o tempo máximo de cada chamada individual ao Pipedrive
+const DefaultTimeout = 8 * time.Second
+
+// DefaultPageLimit é usado quando a definição não informa PageLimit
+const DefaultPageLimit = 500
+
+type FieldMeta struct {
+	ID        int           `json:"id"`
+	Key       string        `json:"key"`
+	Name      string        `json:"name"`
+	FieldType string        `json:"field_type"`
+	Options   []FieldOption `json:"options,omitempty"`
+}
+
+// Resource descreve um recurso do Pipedrive. T é o modelo usado na listagem
+// (e.g. models.Deal) e C o corpo aceito na criação (e.g. DealCreateItem).
+//
+// O payload de criação é o JSON de C: campos com omitempty só são enviados
+// quando informados e o mapa 'custom_fields' é achatado no nível raiz.
+type Resource[T any, C any] struct {
+	// Name é o nome no plural usado em mensagens (e.g. "deals")
+	Name string
+	// Singular é usado em mensagens de item (e.g. "deal")
+	Singular string
+	// Path é o caminho upstream (e.g. "/deals")
+	Path string
+	// FieldsPath é o endpoint de metadados dos campos (e.g. "/dealFields")
+	FieldsPath string
+	// PageLimit é o tamanho de página da listagem
+	PageLimit int
+
+	// Required lista os campos obrigatórios na criação. O primeiro também
+	// identifica um corpo com objeto único.
+	Required []string
+	// ReadOnly lista os campos que não podem ser alterados via PUT
+	ReadOnly map[string]bool
+
+	// OwnerField recebe o ID resolvido a partir de 'owner_email' (vazio desativa)
+	OwnerField string
+	// ListFields são campos em lista (e.g. email/phone) em que o modo 'add'
+	// acrescenta itens em vez de concatenar texto
+	ListFields map[string]bool
+	// UpsertKeys lista os campos aceitos em POST ?upsert=<campo>, com o campo
+	// correspondente em /<recurso>/search. Chaves de campos customizados são
+	// sempre aceitas; vazio desativa o upsert.
+	UpsertKeys map[string]string
+	// LocalFilter aplica utils.FilterSliceByQuery na listagem
+	LocalFilter bool
+	// DryRun habilita ?dry_run=true em POST, PUT e DELETE em massa
+	DryRun bool
+
+	// ExampleSingle e ExampleBulk aparecem nas mensagens de corpo inválido
+	ExampleSingle interface{}
+	ExampleBulk   interface{}
+
+	fields fieldCache
+}
+
+func (res *Resource[T, C]) pageLimit() int {
+	if res.PageLimit > 0 {
+		return res.PageLimit
+	}
+	return DefaultPageLimit
+}
+
+// BulkStatus resume o resultado de uma operação em massa (success,
+// partial_failure ou failure)
+func BulkStatus(success, total int) string {
+	if success == 0 {
+		return "failure"
+	} else if success < total {
+		return "partial_failure"
+	}
+	return "success"
+}
+
+// ExtractUpstreamError extrai a mensagem de erro do corpo retornado pelo Pipedrive
+func ExtractUpstreamError(parsed map[string]interface{}) string {
+	if errVal, ok := parsed["error"]; ok && errVal != nil {
+		switch e := errVal.(type) {
+		case string:
+			return e
+		case map[string]interface{}:
+			if msg, ok := e["message"].(string); ok {
+				return msg
+			}
+			if errList, ok := e["errors"].([]interface{}); ok && len(errList) > 0 {
+				return fmt.Sprint(errList[0])
+			}
+			return fmt.Sprint(e)
+		default:
+			return fmt.Sprint(e)
+		}
+	}
+	return "upstream returned an unspecified error"
+}
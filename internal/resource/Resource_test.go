@@ -0,0 +1,130 @@
+package resource
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/utils"
+)
+
+// Chaves hash de campos customizados usadas nos testes
+var (
+	keySegmento = strings.Repeat("a", 40)
+	keyCNPJ     = strings.Repeat("b", 40)
+	keyCodigo1  = strings.Repeat("c", 40)
+	keyCodigo2  = strings.Repeat("d", 40)
+)
+
+type testRecord map[string]interface{}
+
+// testResource retorna um recurso de deals com o cache de campos já carregado;
+// fields nil deixa o cache vazio (FetchFields consulta o Pipedrive)
+func testResource(fields map[string]FieldMeta) *Resource[testRecord, testRecord] {
+	res := &Resource[testRecord, testRecord]{
+		Name:       "deals",
+		Singular:   "deal",
+		Path:       "/deals",
+		FieldsPath: "/dealFields",
+		ReadOnly:   map[string]bool{"id": true, "add_time": true},
+		UpsertKeys: map[string]string{"title": "title"},
+	}
+	if fields != nil {
+		res.fields.fields = fields
+		res.fields.loadedAt = time.Now()
+	}
+	return res
+}
+
+func testFields() map[string]FieldMeta {
+	return map[string]FieldMeta{
+		"value":       {Key: "value", Name: "Value", FieldType: "monetary"},
+		"expected":    {Key: "expected", Name: "Expected close date", FieldType: "date"},
+		"stage_id":    {Key: "stage_id", Name: "Stage", FieldType: "stage"},
+		"status":      {Key: "status", Name: "Status", FieldType: "enum", Options: []FieldOption{{ID: 1, Label: "open"}}},
+		keySegmento:   {Key: keySegmento, Name: "Segmento", FieldType: "enum", Options: []FieldOption{{ID: 10, Label: "Varejo"}}},
+		keyCNPJ:       {Key: keyCNPJ, Name: "CNPJ", FieldType: "varchar"},
+		keyCodigo1:    {Key: keyCodigo1, Name: "Código", FieldType: "varchar"},
+		keyCodigo2:    {Key: keyCodigo2, Name: "código ", FieldType: "varchar"},
+		"title":       {Key: "title", Name: "Title", FieldType: "varchar"},
+		"probability": {Key: "probability", Name: "Probability", FieldType: "double"},
+	}
+}
+
+// fakePipedrive responde cada caminho com o handler registrado e 404 para os
+// demais; registra as chamadas recebidas
+type fakePipedrive struct {
+	mu       sync.Mutex
+	calls    []string
+	handlers map[string]http.HandlerFunc
+}
+
+func (f *fakePipedrive) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+	handler, ok := f.handlers[r.URL.Path]
+	f.mu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"success":false,"error":"not found"}`))
+		return
+	}
+	w.Header().Set(utils.HeaderContentType, utils.ContentTypeJSON)
+	handler(w, r)
+}
+
+func (f *fakePipedrive) received() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// setupPipedrive inicia o Pipedrive falso e aponta o cliente para ele (sem
+// broker global, o cliente chama o servidor diretamente)
+func setupPipedrive(t *testing.T, handlers map[string]http.HandlerFunc) (*fakePipedrive, *client.PipedriveClient) {
+	t.Helper()
+	fake := &fakePipedrive{handlers: handlers}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	t.Setenv("PIPEDRIVE_BASE_URL", server.URL)
+	t.Setenv("PIPEDRIVE_API_TOKEN", "token")
+	return fake, client.NewPipedriveClient()
+}
+
+func decodeJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", raw, err)
+	}
+	return v
+}
+
+// respondJSON devolve um handler que responde 'status' com 'body'
+func respondJSON(status int, body interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func TestBulkStatus(t *testing.T) {
+	tests := []struct {
+		success, total int
+		want           string
+	}{
+		{success: 3, total: 3, want: "success"},
+		{success: 1, total: 3, want: "partial_failure"},
+		{success: 0, total: 3, want: "failure"},
+	}
+	for _, tt := range tests {
+		if got := BulkStatus(tt.success, tt.total); got != tt.want {
+			t.Errorf("BulkStatus(%d, %d) = %q; want %q", tt.success, tt.total, got, tt.want)
+		}
+	}
+}
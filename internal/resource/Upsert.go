@@ -0,0 +1,236 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/utils"
+)
+
+// Campos customizados do Pipedrive usam chaves hash de 40 caracteres
+var customFieldKeyPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// IsCustomFieldKey indica se 'key' é a chave hash de um campo customizado
+func IsCustomFieldKey(key string) bool {
+	return customFieldKeyPattern.MatchString(key)
+}
+
+// upsertSearchField retorna o campo de /<recurso>/search usado para a chave
+func (res *Resource[T, C]) upsertSearchField(key string) (string, error) {
+	if len(res.UpsertKeys) == 0 {
+		return "", fmt.Errorf("upsert is not supported for %s", res.Name)
+	}
+	if field, ok := res.UpsertKeys[key]; ok {
+		return field, nil
+	}
+	if customFieldKeyPattern.MatchString(key) {
+		return "custom_fields", nil
+	}
+
+	keys := make([]string, 0, len(res.UpsertKeys))
+	for k := range res.UpsertKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return "", fmt.Errorf("unsupported upsert key '%s': use %s or a custom field key or name", key, strings.Join(keys, ", "))
+}
+
+// resolveUpsertKey traduz ?upsert=<nome do campo customizado> (e.g. "CNPJ")
+// para a chave hash, a mesma usada no corpo depois de resolveCustomFields
+func (res *Resource[T, C]) resolveUpsertKey(ctx context.Context, c *client.PipedriveClient, key string) (string, int, error) {
+	if _, ok := res.UpsertKeys[key]; ok || len(res.UpsertKeys) == 0 || customFieldKeyPattern.MatchString(key) {
+		return key, http.StatusOK, nil
+	}
+	fieldsMeta, err := res.FetchFields(ctx, c)
+	if err != nil {
+		return "", http.StatusServiceUnavailable, fmt.Errorf("cannot resolve upsert key '%s': %w", key, err)
+	}
+	index := customFieldsByName(fieldsMeta)
+	if len(index[strings.ToLower(strings.TrimSpace(key))]) == 0 {
+		// segue para upsertSearchField, que lista as chaves aceitas
+		return key, http.StatusOK, nil
+	}
+	resolved, err := lookupCustomField(index, fieldsMeta, key)
+	if err != nil {
+		return "", http.StatusBadRequest, err
+	}
+	return resolved, http.StatusOK, nil
+}
+
+func sameKeyValue(a, b interface{}) bool {
+	return strings.EqualFold(strings.TrimSpace(fmt.Sprint(a)), strings.TrimSpace(fmt.Sprint(b)))
+}
+
+// upsertSearchLimit é o tamanho de cada página de /<recurso>/search
+const upsertSearchLimit = 100
+
+// findByKey retorna os IDs cujo campo 'key' é igual a 'value'. A busca do
+// Pipedrive só filtra candidatos (todas as páginas); o valor é conferido em
+// cada registro. Um candidato que não pode ser lido falha o item, em vez de
+// ser tratado como diferente (o que criaria uma duplicata).
+func (res *Resource[T, C]) findByKey(ctx context.Context, c *client.PipedriveClient, key string, value interface{}) ([]int, int, error) {
+	searchField, err := res.upsertSearchField(key)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	matches := make([]int, 0)
+	offset := 0
+	for {
+		query := url.Values{}
+		query.Set("term", strings.TrimSpace(fmt.Sprint(value)))
+		query.Set("fields", searchField)
+		query.Set("exact_match", "true")
+		query.Set("start", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(upsertSearchLimit))
+
+		page, status, err := res.searchPage(ctx, c, query)
+		if err != nil {
+			return nil, status, err
+		}
+
+		for _, entry := range page.Data.Items {
+			idVal, ok := entry.Item["id"].(float64)
+			if !ok {
+				continue
+			}
+			id := int(idVal)
+
+			candidate, ok := entry.Item[key]
+			if !ok {
+				record, status, err := res.fetchExisting(ctx, c, id)
+				if err != nil {
+					if status < 400 {
+						status = http.StatusServiceUnavailable
+					}
+					return nil, status, fmt.Errorf("check %s %d for upsert: %w", res.Singular, id, err)
+				}
+				candidate = record[key]
+			}
+			if sameKeyValue(candidate, value) {
+				matches = append(matches, id)
+			}
+		}
+
+		pagination := page.AdditionalData.Pagination
+		if !pagination.MoreItemsInCollection || pagination.NextStart <= offset {
+			return matches, http.StatusOK, nil
+		}
+		offset = pagination.NextStart
+	}
+}
+
+type searchResponse struct {
+	Data struct {
+		Items []struct {
+			Item map[string]interface{} `json:"item"`
+		} `json:"items"`
+	} `json:"data"`
+	AdditionalData struct {
+		Pagination struct {
+			MoreItemsInCollection bool `json:"more_items_in_collection"`
+			NextStart             int  `json:"next_start"`
+		} `json:"pagination"`
+	} `json:"additional_data"`
+}
+
+// searchPage lê uma página de /<recurso>/search
+func (res *Resource[T, C]) searchPage(ctx context.Context, c *client.PipedriveClient, query url.Values) (*searchResponse, int, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.Do(reqCtx, utils.HTTPGet, res.Path+"/search", query)
+	if err != nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("search %s: %w", res.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, resp.StatusCode, fmt.Errorf("search %s: upstream returned %d", res.Name, resp.StatusCode)
+	}
+	var page searchResponse
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("search %s: invalid upstream response: %w", res.Name, err)
+	}
+	return &page, http.StatusOK, nil
+}
+
+// upsertUpdate é o item de atualização do registro encontrado pela chave; passa
+// pelas mesmas validações do PUT em massa (campos somente leitura, tipos e
+// 'owner_email')
+func upsertUpdate(id int, payload map[string]interface{}) UpdateItem {
+	return UpdateItem{
+		Type:    string(updateReplace),
+		Verbose: VerbosityFull,
+		ID:      id,
+		Fields:  payload,
+	}
+}
+
+// upsertOne atualiza o único registro com o mesmo valor de 'key', cria um
+// novo quando não há nenhum e reporta conflito (409) quando há mais de um.
+// 'seen' guarda os IDs já gravados nesta requisição, pois a busca do
+// Pipedrive não enxerga imediatamente registros recém-criados.
+func (res *Resource[T, C]) upsertOne(ctx context.Context, c *client.PipedriveClient, key string, payload map[string]interface{}, seen map[string]int) (interface{}, int, error) {
+	value, ok := payload[key]
+	if !ok || isBlank(value) {
+		return nil, http.StatusBadRequest, fmt.Errorf("upsert key '%s' is missing in item", key)
+	}
+	seenKey := strings.ToLower(strings.TrimSpace(fmt.Sprint(value)))
+
+	var matches []int
+	if id, ok := seen[seenKey]; ok {
+		matches = []int{id}
+	} else {
+		found, status, err := res.findByKey(ctx, c, key, value)
+		if err != nil {
+			return nil, status, err
+		}
+		matches = found
+	}
+
+	switch len(matches) {
+	case 0:
+		if err := res.resolveCreateOwner(ctx, c, payload); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		data, status, err := res.createOne(ctx, c, payload)
+		if err != nil {
+			return nil, status, err
+		}
+		if m, ok := data.(map[string]interface{}); ok {
+			if idVal, ok := m["id"].(float64); ok {
+				seen[seenKey] = int(idVal)
+			}
+		}
+		return map[string]interface{}{
+			"action": "created",
+			"data":   data,
+		}, status, nil
+
+	case 1:
+		data, status, err := res.applyUpdate(ctx, c, upsertUpdate(matches[0], payload))
+		if err != nil {
+			return nil, status, err
+		}
+		seen[seenKey] = matches[0]
+		return map[string]interface{}{
+			"action": "updated",
+			"id":     matches[0],
+			"data":   data,
+		}, status, nil
+
+	default:
+		return map[string]interface{}{
+			"matches": matches,
+		}, http.StatusConflict, fmt.Errorf("conflict: %d %s match %s='%v'", len(matches), res.Name, key, value)
+	}
+}
@@ -0,0 +1,268 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"pipedrive_api_service/internal/client"
+)
+
+func TestResolveUpsertKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		want       string
+		wantStatus int
+		wantErr    string
+	}{
+		{name: "standard key", key: "title", want: "title", wantStatus: http.StatusOK},
+		{name: "custom field key", key: keyCNPJ, want: keyCNPJ, wantStatus: http.StatusOK},
+		{name: "custom field name", key: "cnpj", want: keyCNPJ, wantStatus: http.StatusOK},
+		// segue como está; upsertSearchField lista as chaves aceitas
+		{name: "unknown name", key: "email", want: "email", wantStatus: http.StatusOK},
+		{
+			name:       "ambiguous name",
+			key:        "Código",
+			wantStatus: http.StatusBadRequest,
+			wantErr:    "ambiguous custom field name 'Código': matches " + keyCodigo1 + ", " + keyCodigo2 + "; use the field key",
+		},
+	}
+
+	res := testResource(testFields())
+	c := client.NewPipedriveClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, status, err := res.resolveUpsertKey(context.Background(), c, tt.key)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d; want %d (err: %v)", status, tt.wantStatus, err)
+			}
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v; want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("resolveUpsertKey(%q) = %q, %v; want %q", tt.key, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveUpsertKeyWithoutMetadata(t *testing.T) {
+	_, c := setupPipedrive(t, map[string]http.HandlerFunc{
+		"/dealFields": respondJSON(http.StatusInternalServerError, map[string]interface{}{"success": false}),
+	})
+	res := testResource(nil)
+
+	if _, status, err := res.resolveUpsertKey(context.Background(), c, "CNPJ"); status != http.StatusServiceUnavailable || err == nil {
+		t.Fatalf("status = %d, err = %v; want 503", status, err)
+	}
+	// chaves padrão e hash não dependem dos metadados
+	if key, status, err := res.resolveUpsertKey(context.Background(), c, "title"); key != "title" || status != http.StatusOK || err != nil {
+		t.Fatalf("resolveUpsertKey(title) = %q, %d, %v", key, status, err)
+	}
+}
+
+// searchItems monta uma página de /deals/search
+func searchItems(more bool, next int, items ...map[string]interface{}) map[string]interface{} {
+	list := make([]map[string]interface{}, 0, len(items))
+	for _, it := range items {
+		list = append(list, map[string]interface{}{"item": it})
+	}
+	return map[string]interface{}{
+		"success": true,
+		"data":    map[string]interface{}{"items": list},
+		"additional_data": map[string]interface{}{
+			"pagination": map[string]interface{}{"more_items_in_collection": more, "next_start": next},
+		},
+	}
+}
+
+func TestFindByKey(t *testing.T) {
+	record := func(id int, cnpj string) http.HandlerFunc {
+		return respondJSON(http.StatusOK, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": id, keyCNPJ: cnpj},
+		})
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		value      interface{}
+		handlers   map[string]http.HandlerFunc
+		want       []int
+		wantStatus int
+		wantErr    string
+	}{
+		{
+			name:  "value compared ignoring case and spaces",
+			key:   "title",
+			value: "Acme",
+			handlers: map[string]http.HandlerFunc{
+				"/deals/search": respondJSON(http.StatusOK, searchItems(false, 0,
+					map[string]interface{}{"id": 1, "title": " acme "},
+					map[string]interface{}{"id": 2, "title": "Acme Ltda"},
+				)),
+			},
+			want:       []int{1},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "several matches are all returned",
+			key:   "title",
+			value: "Acme",
+			handlers: map[string]http.HandlerFunc{
+				"/deals/search": respondJSON(http.StatusOK, searchItems(false, 0,
+					map[string]interface{}{"id": 1, "title": "Acme"},
+					map[string]interface{}{"id": 3, "title": "ACME"},
+				)),
+			},
+			want:       []int{1, 3},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "all search pages are read",
+			key:   "title",
+			value: "Acme",
+			handlers: map[string]http.HandlerFunc{
+				"/deals/search": func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Query().Get("start") == "0" {
+						respondJSON(http.StatusOK, searchItems(true, 100, map[string]interface{}{"id": 1, "title": "Acme"}))(w, r)
+						return
+					}
+					respondJSON(http.StatusOK, searchItems(false, 0, map[string]interface{}{"id": 9, "title": "Acme"}))(w, r)
+				},
+			},
+			want:       []int{1, 9},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "custom field checked on each candidate",
+			key:   keyCNPJ,
+			value: "123",
+			handlers: map[string]http.HandlerFunc{
+				"/deals/search": respondJSON(http.StatusOK, searchItems(false, 0,
+					map[string]interface{}{"id": 1},
+					map[string]interface{}{"id": 2},
+				)),
+				"/deals/1": record(1, "123"),
+				"/deals/2": record(2, "1234"),
+			},
+			want:       []int{1},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "unreadable candidate fails the item",
+			key:   keyCNPJ,
+			value: "123",
+			handlers: map[string]http.HandlerFunc{
+				"/deals/search": respondJSON(http.StatusOK, searchItems(false, 0, map[string]interface{}{"id": 1})),
+				"/deals/1":      respondJSON(http.StatusInternalServerError, map[string]interface{}{"success": false}),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    "check deal 1 for upsert",
+		},
+		{
+			name:  "missing candidate fails the item",
+			key:   keyCNPJ,
+			value: "123",
+			handlers: map[string]http.HandlerFunc{
+				"/deals/search": respondJSON(http.StatusOK, searchItems(false, 0, map[string]interface{}{"id": 4})),
+			},
+			wantStatus: http.StatusNotFound,
+			wantErr:    "check deal 4 for upsert: deal 4 not found",
+		},
+		{
+			name:       "unsupported key",
+			key:        "email",
+			value:      "a@b.c",
+			wantStatus: http.StatusBadRequest,
+			wantErr:    "unsupported upsert key 'email'",
+		},
+		{
+			name:  "search failure",
+			key:   "title",
+			value: "Acme",
+			handlers: map[string]http.HandlerFunc{
+				"/deals/search": respondJSON(http.StatusTooManyRequests, map[string]interface{}{"success": false}),
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantErr:    "search deals: upstream returned 429",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := setupPipedrive(t, tt.handlers)
+			res := testResource(testFields())
+
+			got, status, err := res.findByKey(context.Background(), c, tt.key, tt.value)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d; want %d (err: %v)", status, tt.wantStatus, err)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v; want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("findByKey = %v, %v; want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertOneConflict(t *testing.T) {
+	fake, c := setupPipedrive(t, map[string]http.HandlerFunc{
+		"/deals/search": respondJSON(http.StatusOK, searchItems(false, 0,
+			map[string]interface{}{"id": 1, "title": "Acme"},
+			map[string]interface{}{"id": 2, "title": "acme"},
+		)),
+	})
+	res := testResource(testFields())
+
+	payload := map[string]interface{}{"title": "Acme", "value": 10}
+	result, status, err := res.upsertOne(context.Background(), c, "title", payload, map[string]int{})
+	if status != http.StatusConflict || err == nil {
+		t.Fatalf("status = %d, err = %v; want 409", status, err)
+	}
+	if want := "conflict: 2 deals match title='Acme'"; err.Error() != want {
+		t.Fatalf("error = %q; want %q", err, want)
+	}
+	if !reflect.DeepEqual(result, map[string]interface{}{"matches": []int{1, 2}}) {
+		t.Fatalf("result = %v; want the matching IDs", result)
+	}
+
+	// um conflito não grava nada
+	for _, call := range fake.received() {
+		if !strings.HasPrefix(call, http.MethodGet+" ") {
+			t.Fatalf("unexpected write %s", call)
+		}
+	}
+}
+
+func TestUpsertOneSeenKey(t *testing.T) {
+	fake, c := setupPipedrive(t, map[string]http.HandlerFunc{
+		"/deals/7": respondJSON(http.StatusOK, map[string]interface{}{"success": true, "data": map[string]interface{}{"id": 7}}),
+	})
+	res := testResource(testFields())
+
+	// um registro gravado por um item anterior não é buscado de novo
+	seen := map[string]int{"acme": 7}
+	result, status, err := res.upsertOne(context.Background(), c, "title", map[string]interface{}{"title": " ACME "}, seen)
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("upsertOne = %v, %d, %v", result, status, err)
+	}
+	if m, _ := result.(map[string]interface{}); m["action"] != "updated" || m["id"] != 7 {
+		t.Fatalf("result = %v; want an update of deal 7", result)
+	}
+	if got := fake.received(); !reflect.DeepEqual(got, []string{"PUT /deals/7"}) {
+		t.Fatalf("calls = %v; want only the update", got)
+	}
+}
@@ -0,0 +1,123 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"pipedrive_api_service/internal/client"
+)
+
+// Verbosity define o retorno de uma atualização:
+//   - false (padrão): {success, fields_altered}
+//   - true: o registro atualizado devolvido pelo Pipedrive
+//   - "diff": {success, diff: {campo: {old, new}}} com os campos que mudaram
+type Verbosity string
+
+const (
+	VerbosityFields Verbosity = ""
+	VerbosityFull   Verbosity = "full"
+	VerbosityDiff   Verbosity = "diff"
+)
+
+// ParseVerbosity aceita os valores de ?verbose= e do campo 'verbose'
+func ParseVerbosity(s string) (Verbosity, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "diff":
+		return VerbosityDiff, nil
+	case "full":
+		return VerbosityFull, nil
+	case "":
+		return VerbosityFields, nil
+	}
+	full, err := strconv.ParseBool(s)
+	if err != nil {
+		return VerbosityFields, fmt.Errorf("unsupported verbose value '%s': use true, false or diff", s)
+	}
+	if full {
+		return VerbosityFull, nil
+	}
+	return VerbosityFields, nil
+}
+
+// UnmarshalJSON aceita booleano (compatível com o formato anterior) ou texto
+func (v *Verbosity) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*v = VerbosityFields
+		if b {
+			*v = VerbosityFull
+		}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("verbose must be a boolean or \"diff\"")
+	}
+	parsed, err := ParseVerbosity(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+func (v Verbosity) MarshalJSON() ([]byte, error) {
+	switch v {
+	case VerbosityFull:
+		return []byte("true"), nil
+	case VerbosityDiff:
+		return json.Marshal(string(VerbosityDiff))
+	}
+	return []byte("false"), nil
+}
+
+// fieldDiff retorna {campo: {old, new}} para cada campo enviado cujo valor
+// mudou. Campos customizados aparecem pelo nome (com a chave em 'key'); se o
+// nome coincide com o de outro campo do diff, ficam pela chave.
+func (res *Resource[T, C]) fieldDiff(ctx context.Context, c *client.PipedriveClient, before, after, fields map[string]interface{}) map[string]interface{} {
+	fieldsMeta, _ := res.FetchFields(ctx, c)
+
+	diff := make(map[string]interface{})
+	named := make(map[string]map[string]interface{})
+	for k, sent := range fields {
+		newVal, ok := after[k]
+		if !ok {
+			newVal = sent
+		}
+		if sameValue(before[k], newVal) {
+			continue
+		}
+
+		entry := map[string]interface{}{
+			"old": before[k],
+			"new": newVal,
+		}
+		if _, ok := fieldsMeta[k]; ok && customFieldKeyPattern.MatchString(k) {
+			entry["key"] = k
+			named[k] = entry
+			continue
+		}
+		diff[k] = entry
+	}
+
+	// os nomes são atribuídos depois dos campos padrão, em ordem de chave,
+	// para que o resultado não dependa da ordem do mapa
+	keys := make([]string, 0, len(named))
+	for k := range named {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := fieldsMeta[k].Name
+		if _, taken := diff[name]; taken {
+			name = k
+		}
+		diff[name] = named[k]
+	}
+	return diff
+}
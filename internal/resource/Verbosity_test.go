@@ -0,0 +1,140 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"pipedrive_api_service/internal/client"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Verbosity
+		wantErr bool
+	}{
+		{in: "", want: VerbosityFields},
+		{in: "false", want: VerbosityFields},
+		{in: "0", want: VerbosityFields},
+		{in: "true", want: VerbosityFull},
+		{in: "1", want: VerbosityFull},
+		{in: "full", want: VerbosityFull},
+		{in: " Diff ", want: VerbosityDiff},
+		{in: "verbose", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseVerbosity(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseVerbosity(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerbosityJSON(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    Verbosity
+		wantErr bool
+	}{
+		{raw: `true`, want: VerbosityFull},
+		{raw: `false`, want: VerbosityFields},
+		{raw: `"diff"`, want: VerbosityDiff},
+		{raw: `"true"`, want: VerbosityFull},
+		{raw: `1`, wantErr: true},
+		{raw: `"loud"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			var v Verbosity
+			err := json.Unmarshal([]byte(tt.raw), &v)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", v)
+				}
+				return
+			}
+			if err != nil || v != tt.want {
+				t.Fatalf("unmarshal %s = %q, %v; want %q", tt.raw, v, err, tt.want)
+			}
+
+			// o formato gravado é lido de volta com o mesmo valor
+			out, _ := json.Marshal(v)
+			var back Verbosity
+			if err := json.Unmarshal(out, &back); err != nil || back != v {
+				t.Fatalf("round trip %s = %q, %v; want %q", out, back, err, v)
+			}
+		})
+	}
+}
+
+func TestFieldDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		fields string
+		want   string
+	}{
+		{
+			name:   "changed and unchanged fields",
+			before: `{"title": "Antigo", "value": 100}`,
+			after:  `{"title": "Novo", "value": 100}`,
+			fields: `{"title": "Novo", "value": "100"}`,
+			want:   `{"title": {"old": "Antigo", "new": "Novo"}}`,
+		},
+		{
+			name:   "custom field by name",
+			before: `{"` + keyCNPJ + `": "1"}`,
+			after:  `{"` + keyCNPJ + `": "2"}`,
+			fields: `{"` + keyCNPJ + `": "2"}`,
+			want:   `{"CNPJ": {"old": "1", "new": "2", "key": "` + keyCNPJ + `"}}`,
+		},
+		{
+			name:   "field missing in the response uses the sent value",
+			before: `{"title": "Antigo"}`,
+			after:  `{}`,
+			fields: `{"title": "Novo"}`,
+			want:   `{"title": {"old": "Antigo", "new": "Novo"}}`,
+		},
+		{
+			name:   "name taken by a standard field keeps the key",
+			before: `{"CNPJ": "x", "` + keyCNPJ + `": "1"}`,
+			after:  `{"CNPJ": "y", "` + keyCNPJ + `": "2"}`,
+			fields: `{"CNPJ": "y", "` + keyCNPJ + `": "2"}`,
+			want:   `{"CNPJ": {"old": "x", "new": "y"}, "` + keyCNPJ + `": {"old": "1", "new": "2", "key": "` + keyCNPJ + `"}}`,
+		},
+		{
+			name:   "nothing changed",
+			before: `{"title": "Igual"}`,
+			after:  `{"title": "Igual"}`,
+			fields: `{"title": "Igual"}`,
+			want:   `{}`,
+		},
+	}
+
+	res := testResource(testFields())
+	c := client.NewPipedriveClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, _ := decodeJSON(t, tt.before).(map[string]interface{})
+			after, _ := decodeJSON(t, tt.after).(map[string]interface{})
+			fields, _ := decodeJSON(t, tt.fields).(map[string]interface{})
+
+			got := res.fieldDiff(context.Background(), c, before, after, fields)
+			raw, _ := json.Marshal(got)
+			if !reflect.DeepEqual(decodeJSON(t, string(raw)), decodeJSON(t, tt.want)) {
+				t.Fatalf("diff = %s; want %s", raw, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/routes/activities"
+	"pipedrive_api_service/internal/utils"
+)
+
+const ActivitiesPageLimit = 500
+
+// Filtros resolvidos pelo próprio Pipedrive no GET /activities
+var activityUpstreamFilters = []string{"start_date", "end_date", "done", "user_id", "type", "filter_id"}
+
+func activitiesUpstreamCall(ctx context.Context, c *client.PipedriveClient, query url.Values, dataContainer interface{}) (*utils.RateLimitInfo, int, error) {
+	finalResponse := dataContainer.(*models.ActivitiesResponse)
+	finalResponse.Data = []models.Activity{}
+
+	rateLimitInfo := &utils.RateLimitInfo{}
+	upstreamStatus := http.StatusOK
+
+	upstreamQuery := url.Values{}
+	for _, key := range activityUpstreamFilters {
+		if v := query.Get(key); v != "" {
+			upstreamQuery.Set(key, v)
+		}
+	}
+	// O Pipedrive espera done=0/1
+	if done := upstreamQuery.Get("done"); done != "" {
+		parsed, err := strconv.ParseBool(done)
+		if err != nil {
+			return rateLimitInfo, http.StatusBadRequest, fmt.Errorf("invalid value for 'done': %s", done)
+		}
+		if parsed {
+			upstreamQuery.Set("done", "1")
+		} else {
+			upstreamQuery.Set("done", "0")
+		}
+	}
+
+	pageFilter := query.Get("page")
+	isPageAll := pageFilter == "all"
+	start := 0
+	if pageFilter != "" && !isPageAll {
+		page, err := strconv.Atoi(pageFilter)
+		if err == nil && page > 0 {
+			start = (page - 1) * ActivitiesPageLimit
+		}
+	}
+
+	limit := query.Get("limit")
+	if limit == "" {
+		limit = fmt.Sprintf("%d", ActivitiesPageLimit)
+	}
+	upstreamQuery.Set("limit", limit)
+
+	for {
+		if ctx.Err() != nil {
+			return rateLimitInfo, http.StatusGatewayTimeout, fmt.Errorf("gateway process cancelled: %w", ctx.Err())
+		}
+
+		currentQuery := make(url.Values)
+		for k, v := range upstreamQuery {
+			currentQuery[k] = v
+		}
+		currentQuery.Set("start", fmt.Sprintf("%d", start))
+
+		resp, body, rate, err := c.Do(ctx, utils.HTTPGet, "/activities", currentQuery)
+		if err != nil {
+			return rate, http.StatusServiceUnavailable, err
+		}
+
+		if rate != nil {
+			rateLimitInfo = rate
+		}
+		upstreamStatus = resp.StatusCode
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return rateLimitInfo, upstreamStatus, fmt.Errorf("upstream returned status: %s", resp.Status)
+		}
+
+		tempResponse := models.ActivitiesResponse{}
+		if err := json.Unmarshal(body, &tempResponse); err != nil {
+			resp.Body.Close()
+			return rateLimitInfo, http.StatusInternalServerError, fmt.Errorf("failed to parse upstream response: %w", err)
+		}
+		resp.Body.Close()
+
+		finalResponse.Data = append(finalResponse.Data, tempResponse.Data...)
+
+		if !isPageAll || !tempResponse.AdditionalData.Pagination.MoreItemsInCollection {
+			break
+		}
+
+		start = tempResponse.AdditionalData.Pagination.NextStart
+	}
+
+	return rateLimitInfo, upstreamStatus, nil
+}
+
+func ActivitiesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		envelope := &models.ActivitiesResponse{}
+		upstreamParams := append([]string{"page", "limit"}, activityUpstreamFilters...)
+		HandlerWrapper(activitiesUpstreamCall, envelope, "/activities", upstreamParams...)(w, r)
+	case http.MethodPost:
+		activities.HandlePost(w, r)
+	case http.MethodPut:
+		activities.HandlePut(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+// ActivitiesDoneHandler marca atividades como concluídas (ou reabre com ?done=false)
+func ActivitiesDoneHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		done := true
+		if v := strings.TrimSpace(r.URL.Query().Get("done")); v != "" {
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				utils.JSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid value for 'done': %s", v), nil)
+				return
+			}
+			done = parsed
+		}
+		activities.HandleMarkDone(w, r, done)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
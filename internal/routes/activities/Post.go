@@ -0,0 +1,221 @@
+package activities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+type ActivityCreateItem struct {
+	Subject           string `json:"subject"`
+	Type              string `json:"type,omitempty"`
+	DueDate           string `json:"due_date,omitempty"`
+	DueTime           string `json:"due_time,omitempty"`
+	Duration          string `json:"duration,omitempty"`
+	UserID            *int   `json:"user_id,omitempty"`
+	DealID            *int   `json:"deal_id,omitempty"`
+	PersonID          *int   `json:"person_id,omitempty"`
+	OrgID             *int   `json:"org_id,omitempty"`
+	LeadID            string `json:"lead_id,omitempty"`
+	Note              string `json:"note,omitempty"`
+	Location          string `json:"location,omitempty"`
+	PublicDescription string `json:"public_description,omitempty"`
+	BusyFlag          *bool  `json:"busy_flag,omitempty"`
+	Done              *bool  `json:"done,omitempty"`
+}
+
+func HandlePost(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	bodyRaw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "failed to read request body",
+			"detail":  err.Error(),
+		}, nil)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(bodyRaw) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "request body is empty",
+			"hint":    "provide at least one activity to create",
+		}, nil)
+		return
+	}
+
+	var items []ActivityCreateItem
+	if err := json.Unmarshal(bodyRaw, &items); err != nil {
+		var single ActivityCreateItem
+		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil && strings.TrimSpace(single.Subject) != "" {
+			items = append(items, single)
+		} else {
+			utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+				"message": "invalid JSON format",
+				"hint":    "body must be either a single object or an array of objects",
+				"example_single": map[string]string{
+					"subject":  "Ligação de follow-up",
+					"type":     "call",
+					"due_date": "2025-01-31",
+					"due_time": "14:30",
+				},
+				"example_bulk": []map[string]string{
+					{"subject": "Reunião A", "type": "meeting"},
+					{"subject": "Reunião B", "type": "meeting"},
+				},
+			}, nil)
+			return
+		}
+	}
+
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "no valid activities found in body",
+			"hint":    "check your JSON structure and required fields",
+		}, nil)
+		return
+	}
+
+	results := make(map[string]interface{}, len(items))
+	success := 0
+
+	for i, item := range items {
+		indexKey := fmt.Sprintf("%d", i)
+
+		if strings.TrimSpace(item.Subject) == "" {
+			results[indexKey] = map[string]interface{}{
+				"error":  "field 'subject' is required",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		payload := map[string]interface{}{
+			"subject": item.Subject,
+		}
+		if item.Type != "" {
+			payload["type"] = item.Type
+		}
+		if item.DueDate != "" {
+			payload["due_date"] = item.DueDate
+		}
+		if item.DueTime != "" {
+			payload["due_time"] = item.DueTime
+		}
+		if item.Duration != "" {
+			payload["duration"] = item.Duration
+		}
+		if item.UserID != nil {
+			payload["user_id"] = *item.UserID
+		}
+		if item.DealID != nil {
+			payload["deal_id"] = *item.DealID
+		}
+		if item.PersonID != nil {
+			payload["person_id"] = *item.PersonID
+		}
+		if item.OrgID != nil {
+			payload["org_id"] = *item.OrgID
+		}
+		if item.LeadID != "" {
+			payload["lead_id"] = item.LeadID
+		}
+		if item.Note != "" {
+			payload["note"] = item.Note
+		}
+		if item.Location != "" {
+			payload["location"] = item.Location
+		}
+		if item.PublicDescription != "" {
+			payload["public_description"] = item.PublicDescription
+		}
+		if item.BusyFlag != nil {
+			payload["busy_flag"] = *item.BusyFlag
+		}
+		if item.Done != nil {
+			payload["done"] = doneFlag(*item.Done)
+		}
+
+		if validationErr := validateSchedule(payload); validationErr != nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  validationErr.Error(),
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		bodyBytes, _ := json.Marshal(payload)
+
+		reqCtx, cancel := context.WithTimeout(r.Context(), resource.DefaultTimeout)
+		resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPost, "/activities", nil, bytes.NewReader(bodyBytes))
+		cancel()
+
+		if err != nil || resp == nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  fmt.Sprintf("failed to reach upstream Pipedrive: %v", err),
+				"status": http.StatusServiceUnavailable,
+			}
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			var parsed map[string]interface{}
+			_ = json.Unmarshal(body, &parsed)
+			results[indexKey] = map[string]interface{}{
+				"error":  resource.ExtractUpstreamError(parsed),
+				"status": resp.StatusCode,
+			}
+			continue
+		}
+
+		var parsed map[string]interface{}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  fmt.Sprintf("unable to parse upstream response: %v", err),
+				"status": http.StatusInternalServerError,
+			}
+			continue
+		}
+
+		if data, ok := parsed["data"]; ok {
+			results[indexKey] = data
+			success++
+		} else {
+			results[indexKey] = map[string]interface{}{
+				"warning": "upstream response missing 'data' field",
+				"status":  resp.StatusCode,
+			}
+		}
+	}
+
+	finalStatus := resource.BulkStatus(success, len(items))
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/activities (create bulk)",
+		http.StatusCreated,
+		nil,
+	)
+
+	utils.JSONOK(w, map[string]interface{}{
+		"status":  finalStatus,
+		"results": results,
+	}, meta)
+}
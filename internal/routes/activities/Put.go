@@ -0,0 +1,193 @@
+package activities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+type ActivityUpdateItem struct {
+	Verbose bool                   `json:"verbose,omitempty"`
+	ID      int                    `json:"id"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Campos bloqueados para edição direta
+var readOnlyFields = map[string]bool{
+	"company_id":          true,
+	"add_time":            true,
+	"update_time":         true,
+	"marked_as_done_time": true,
+}
+
+var (
+	dueTimePattern  = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`)
+	durationPattern = regexp.MustCompile(`^\d{2}:[0-5]\d$`)
+)
+
+func HandlePut(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+
+	var items []ActivityUpdateItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid JSON body", nil)
+		return
+	}
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, "empty update list", nil)
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	results := make(map[string]interface{}, len(items))
+	success := 0
+
+	for _, it := range items {
+		idStr := strconv.Itoa(it.ID)
+
+		if it.ID <= 0 {
+			results[idStr] = map[string]interface{}{
+				"error":  "invalid id",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if len(it.Fields) == 0 {
+			results[idStr] = map[string]interface{}{
+				"error":  "fields required for update",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if validationErr := validateFields(it.Fields); validationErr != nil {
+			results[idStr] = map[string]interface{}{
+				"error":  validationErr.Error(),
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		res, status, err := doUpdate(r.Context(), c, it.ID, it.Fields, it.Verbose)
+		if err != nil {
+			results[idStr] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": status,
+			}
+			continue
+		}
+		results[idStr] = res
+		success++
+	}
+
+	finalStatus := resource.BulkStatus(success, len(items))
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/activities (bulk)",
+		http.StatusMultiStatus,
+		nil,
+	)
+
+	utils.JSONOK(w, models.BulkUpdateResult{
+		Status:  finalStatus,
+		Results: results,
+	}, meta)
+}
+
+// --- Helpers ---
+
+func doUpdate(ctx context.Context, c *client.PipedriveClient, id int, fields map[string]interface{}, verbose bool) (interface{}, int, error) {
+	if done, ok := fields["done"].(bool); ok {
+		fields["done"] = doneFlag(done)
+	}
+
+	bodyBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("marshal fields: %w", err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, resource.DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPut, "/activities/"+strconv.Itoa(id), nil, bytes.NewReader(bodyBytes))
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		return nil, status, fmt.Errorf("update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+
+	if resp.StatusCode >= 400 {
+		return parsed, resp.StatusCode, errors.New(resource.ExtractUpstreamError(parsed))
+	}
+
+	if !verbose {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		return map[string]interface{}{
+			"success":        true,
+			"fields_altered": keys,
+		}, resp.StatusCode, nil
+	}
+	return parsed["data"], resp.StatusCode, nil
+}
+
+// doneFlag converte o booleano para o formato 0/1 esperado pelo Pipedrive
+func doneFlag(done bool) int {
+	if done {
+		return 1
+	}
+	return 0
+}
+
+func validateFields(fields map[string]interface{}) error {
+	for k := range fields {
+		if readOnlyFields[k] {
+			return fmt.Errorf("field '%s' is read-only and cannot be modified", k)
+		}
+	}
+	return validateSchedule(fields)
+}
+
+// validateSchedule valida os formatos de data/hora aceitos pelo Pipedrive
+func validateSchedule(fields map[string]interface{}) error {
+	if v, ok := fields["due_date"]; ok && v != "" {
+		if _, err := time.Parse("2006-01-02", fmt.Sprint(v)); err != nil {
+			return fmt.Errorf("invalid value for 'due_date': expected YYYY-MM-DD")
+		}
+	}
+	if v, ok := fields["due_time"]; ok && v != "" {
+		if !dueTimePattern.MatchString(fmt.Sprint(v)) {
+			return fmt.Errorf("invalid value for 'due_time': expected HH:MM")
+		}
+	}
+	if v, ok := fields["duration"]; ok && v != "" {
+		if !durationPattern.MatchString(fmt.Sprint(v)) {
+			return fmt.Errorf("invalid value for 'duration': expected HH:MM")
+		}
+	}
+	return nil
+}
@@ -3,21 +3,43 @@ package routes
 import (
 	"net/http"
 
-	org "pipedrive_api_service/internal/routes/deals"
+	"pipedrive_api_service/internal/routes/deals"
 	"pipedrive_api_service/internal/utils"
 )
 
 func DealsHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		org.HandleGet(w, r)
+		deals.Resource.HandleGet(w, r)
 	case http.MethodPost:
-		org.HandlePost(w, r)
+		deals.Resource.HandlePost(w, r)
 	case http.MethodDelete:
-		org.HandleDelete(w, r)
+		deals.Resource.HandleDelete(w, r)
 	case http.MethodPut:
-		org.HandlePut(w, r)
+		deals.Resource.HandlePut(w, r)
+	case http.MethodPatch:
+		deals.Resource.HandlePatch(w, r)
 	default:
 		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
 	}
 }
+
+// DealHandler atende /pipedrive/deals/{id}
+func DealHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		deals.Resource.HandleGetByID(w, r)
+	case http.MethodPut:
+		deals.Resource.HandlePutByID(w, r)
+	case http.MethodPatch:
+		deals.Resource.HandlePatchByID(w, r)
+	case http.MethodDelete:
+		deals.Resource.HandleDeleteByID(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+func DealProductsHandler(w http.ResponseWriter, r *http.Request) {
+	deals.HandleProducts(w, r)
+}
@@ -0,0 +1,316 @@
+package deals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+// DealProductItem representa uma operação sobre um produto anexado a um deal.
+// Na criação (POST) ProductID, ItemPrice e Quantity são obrigatórios; na
+// atualização (PUT) e remoção (DELETE) ProductAttachmentID identifica a linha.
+type DealProductItem struct {
+	DealID              int                    `json:"deal_id"`
+	ProductAttachmentID int                    `json:"product_attachment_id,omitempty"`
+	ProductID           int                    `json:"product_id,omitempty"`
+	ItemPrice           *float64               `json:"item_price,omitempty"`
+	Quantity            *float64               `json:"quantity,omitempty"`
+	Discount            *float64               `json:"discount,omitempty"`
+	DiscountType        string                 `json:"discount_type,omitempty"`
+	Tax                 *float64               `json:"tax,omitempty"`
+	Comments            string                 `json:"comments,omitempty"`
+	ProductVariationID  *int                   `json:"product_variation_id,omitempty"`
+	EnabledFlag         *bool                  `json:"enabled_flag,omitempty"`
+	Fields              map[string]interface{} `json:"fields,omitempty"`
+}
+
+type dealProductOp string
+
+const (
+	dealProductAttach dealProductOp = "attach"
+	dealProductUpdate dealProductOp = "update"
+	dealProductDetach dealProductOp = "detach"
+)
+
+// HandleProducts lista (GET ?deal_id=), anexa (POST), atualiza (PUT) e remove
+// (DELETE) produtos de deals. Cada resultado inclui o 'value' recalculado do deal.
+func HandleProducts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		handleListDealProducts(w, r)
+	case http.MethodPost:
+		handleDealProductsBulk(w, r, dealProductAttach)
+	case http.MethodPut:
+		handleDealProductsBulk(w, r, dealProductUpdate)
+	case http.MethodDelete:
+		handleDealProductsBulk(w, r, dealProductDetach)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+func handleListDealProducts(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	dealID, err := strconv.Atoi(r.URL.Query().Get("deal_id"))
+	if err != nil || dealID <= 0 {
+		utils.JSONError(w, http.StatusBadRequest, "query parameter 'deal_id' is required", nil)
+		return
+	}
+
+	path := fmt.Sprintf("/deals/%d/products", dealID)
+	reqCtx, cancel := context.WithTimeout(r.Context(), resource.DefaultTimeout)
+	defer cancel()
+
+	resp, body, rate, err := c.Do(reqCtx, utils.HTTPGet, path, url.Values{"limit": {"500"}})
+	meta := utils.NewMetaItem(start, r.Header.Get(utils.HeaderXRequestID), c.BaseURL()+path, http.StatusServiceUnavailable, rate)
+	if err != nil {
+		utils.JSONError(w, http.StatusServiceUnavailable, err.Error(), meta)
+		return
+	}
+	meta.Status = resp.StatusCode
+
+	if resp.StatusCode != http.StatusOK {
+		utils.JSONError(w, resp.StatusCode, fmt.Sprintf("upstream returned status: %s", resp.Status), meta)
+		return
+	}
+
+	var parsed struct {
+		Success bool                 `json:"success"`
+		Data    []models.DealProduct `json:"data"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		meta.Status = http.StatusInternalServerError
+		utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to parse upstream response: %v", err), meta)
+		return
+	}
+	if parsed.Data == nil {
+		parsed.Data = []models.DealProduct{}
+	}
+
+	meta.Extra = &utils.ExtraMeta{TotalResults: len(parsed.Data)}
+	utils.JSONOK(w, map[string]interface{}{
+		"deal_id":  dealID,
+		"products": parsed.Data,
+	}, meta)
+}
+
+func handleDealProductsBulk(w http.ResponseWriter, r *http.Request, op dealProductOp) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	bodyRaw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "failed to read request body",
+			"detail":  err.Error(),
+		}, nil)
+		return
+	}
+	defer r.Body.Close()
+
+	var items []DealProductItem
+	if err := json.Unmarshal(bodyRaw, &items); err != nil {
+		var single DealProductItem
+		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil && single.DealID > 0 {
+			items = append(items, single)
+		}
+	}
+
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "no valid deal products found in body",
+			"hint":    "body must be either a single object or an array of objects with 'deal_id'",
+			"example_attach": map[string]interface{}{
+				"deal_id": 123, "product_id": 10, "item_price": 1200, "quantity": 2, "discount": 10, "discount_type": "percentage",
+			},
+			"example_update": map[string]interface{}{
+				"deal_id": 123, "product_attachment_id": 55, "fields": map[string]interface{}{"quantity": 3},
+			},
+			"example_detach": map[string]interface{}{
+				"deal_id": 123, "product_attachment_id": 55,
+			},
+		}, nil)
+		return
+	}
+
+	results := make(map[string]interface{}, len(items))
+	success := 0
+
+	for i, item := range items {
+		indexKey := fmt.Sprintf("%d", i)
+
+		res, status, err := doDealProductOp(r.Context(), c, op, item)
+		if err != nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": status,
+			}
+			continue
+		}
+		results[indexKey] = res
+		success++
+	}
+
+	finalStatus := "success"
+	if success == 0 {
+		finalStatus = "failure"
+	} else if success < len(items) {
+		finalStatus = "partial_failure"
+	}
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+fmt.Sprintf("/deals/{id}/products (%s bulk)", op),
+		http.StatusOK,
+		nil,
+	)
+
+	utils.JSONOK(w, map[string]interface{}{
+		"status":  finalStatus,
+		"results": results,
+	}, meta)
+}
+
+func doDealProductOp(ctx context.Context, c *client.PipedriveClient, op dealProductOp, item DealProductItem) (map[string]interface{}, int, error) {
+	if item.DealID <= 0 {
+		return nil, http.StatusBadRequest, errors.New("field 'deal_id' is required")
+	}
+
+	if item.DiscountType != "" && item.DiscountType != "percentage" && item.DiscountType != "amount" {
+		return nil, http.StatusBadRequest, errors.New("invalid value for 'discount_type': must be 'percentage' or 'amount'")
+	}
+
+	var (
+		method  utils.HTTPMethod
+		path    string
+		payload map[string]interface{}
+	)
+
+	switch op {
+	case dealProductAttach:
+		if item.ProductID <= 0 {
+			return nil, http.StatusBadRequest, errors.New("field 'product_id' is required")
+		}
+		if item.ItemPrice == nil || item.Quantity == nil {
+			return nil, http.StatusBadRequest, errors.New("fields 'item_price' and 'quantity' are required")
+		}
+		method = utils.HTTPPost
+		path = fmt.Sprintf("/deals/%d/products", item.DealID)
+		payload = dealProductPayload(item)
+		payload["product_id"] = item.ProductID
+
+	case dealProductUpdate:
+		if item.ProductAttachmentID <= 0 {
+			return nil, http.StatusBadRequest, errors.New("field 'product_attachment_id' is required")
+		}
+		method = utils.HTTPPut
+		path = fmt.Sprintf("/deals/%d/products/%d", item.DealID, item.ProductAttachmentID)
+		payload = dealProductPayload(item)
+		for k, v := range item.Fields {
+			payload[k] = v
+		}
+		if len(payload) == 0 {
+			return nil, http.StatusBadRequest, errors.New("fields required for update")
+		}
+
+	case dealProductDetach:
+		if item.ProductAttachmentID <= 0 {
+			return nil, http.StatusBadRequest, errors.New("field 'product_attachment_id' is required")
+		}
+		method = utils.HTTPDelete
+		path = fmt.Sprintf("/deals/%d/products/%d", item.DealID, item.ProductAttachmentID)
+	}
+
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyBytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("marshal fields: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, resource.DefaultTimeout)
+	resp, body, _, err := c.DoWithBody(reqCtx, method, path, nil, bodyReader)
+	cancel()
+	if err != nil || resp == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("failed to reach upstream Pipedrive: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+
+	if resp.StatusCode >= 400 {
+		return nil, resp.StatusCode, errors.New(resource.ExtractUpstreamError(parsed))
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"deal_id": item.DealID,
+	}
+	if op == dealProductDetach {
+		result["detached"] = item.ProductAttachmentID
+	} else {
+		result["product"] = parsed["data"]
+	}
+
+	// O Pipedrive recalcula o 'value' do deal a cada alteração de produtos
+	deal, _, err := Resource.FetchAsMap(ctx, c, item.DealID, nil)
+	if err != nil {
+		result["deal_value_error"] = err.Error()
+	} else {
+		result["deal_value"] = deal["value"]
+		result["currency"] = deal["currency"]
+	}
+
+	return result, resp.StatusCode, nil
+}
+
+func dealProductPayload(item DealProductItem) map[string]interface{} {
+	payload := map[string]interface{}{}
+	if item.ItemPrice != nil {
+		payload["item_price"] = *item.ItemPrice
+	}
+	if item.Quantity != nil {
+		payload["quantity"] = *item.Quantity
+	}
+	if item.Discount != nil {
+		payload["discount"] = *item.Discount
+	}
+	if item.DiscountType != "" {
+		payload["discount_type"] = item.DiscountType
+	}
+	if item.Tax != nil {
+		payload["tax"] = *item.Tax
+	}
+	if item.Comments != "" {
+		payload["comments"] = item.Comments
+	}
+	if item.ProductVariationID != nil {
+		payload["product_variation_id"] = *item.ProductVariationID
+	}
+	if item.EnabledFlag != nil {
+		payload["enabled_flag"] = *item.EnabledFlag
+	}
+	return payload
+}
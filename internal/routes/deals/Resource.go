@@ -0,0 +1,51 @@
+package deals
+
+import (
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+)
+
+type DealCreateItem struct {
+	Title        string                 `json:"title"`
+	Value        *float64               `json:"value,omitempty"`
+	Currency     string                 `json:"currency,omitempty"`
+	UserID       *int                   `json:"user_id,omitempty"`
+	OwnerEmail   string                 `json:"owner_email,omitempty"`
+	PipelineID   *int                   `json:"pipeline_id,omitempty"`
+	StageID      *int                   `json:"stage_id,omitempty"`
+	OrgID        *int                   `json:"org_id,omitempty"`
+	PersonID     *int                   `json:"person_id,omitempty"`
+	VisibleTo    *int                   `json:"visible_to,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Resource define o CRUD de /deals
+var Resource = &resource.Resource[models.Deal, DealCreateItem]{
+	Name:       "deals",
+	Singular:   "deal",
+	Path:       "/deals",
+	FieldsPath: "/dealFields",
+	Required:   []string{"title"},
+	// Campos bloqueados para edição direta
+	ReadOnly: map[string]bool{
+		"creator_user_id":   true,
+		"owner_id":          true,
+		"stage_change_time": true,
+		"add_time":          true,
+		"update_time":       true,
+		"status":            false, // permitido
+	},
+	OwnerField: "user_id",
+	DryRun:     true,
+	UpsertKeys: map[string]string{
+		"title": "title",
+	},
+	ExampleSingle: map[string]string{
+		"title": "New Sales Opportunity",
+	},
+	ExampleBulk: []map[string]string{
+		{"title": "Deal A"},
+		{"title": "Deal B"},
+	},
+}
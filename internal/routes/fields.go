@@ -0,0 +1,113 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/resource"
+
+	"pipedrive_api_service/internal/routes/deals"
+	org "pipedrive_api_service/internal/routes/organizations"
+	person "pipedrive_api_service/internal/routes/persons"
+	product "pipedrive_api_service/internal/routes/products"
+	"pipedrive_api_service/internal/utils"
+)
+
+// fieldsResource é a parte de resource.Resource usada por /pipedrive/fields/{entity}
+type fieldsResource interface {
+	HandleFieldsGet(w http.ResponseWriter, r *http.Request)
+	HandleFieldsPost(w http.ResponseWriter, r *http.Request)
+	HandleFieldsPut(w http.ResponseWriter, r *http.Request)
+	HandleFieldsDelete(w http.ResponseWriter, r *http.Request)
+	RefreshFields(ctx context.Context, c *client.PipedriveClient) (int, error)
+	FieldsAge() (time.Duration, bool)
+}
+
+var fieldEntities = map[string]fieldsResource{
+	"deals":         deals.Resource,
+	"organizations": org.Resource,
+	"persons":       person.Resource,
+	"products":      product.Resource,
+}
+
+// FieldsHandler atende /pipedrive/fields/{entity}
+func FieldsHandler(w http.ResponseWriter, r *http.Request) {
+	entity := r.PathValue("entity")
+	res, ok := fieldEntities[entity]
+	if !ok {
+		utils.JSONError(w, http.StatusNotFound, fmt.Sprintf("unknown entity '%s': use deals, organizations, persons or products", entity), nil)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		res.HandleFieldsGet(w, r)
+	case http.MethodPost:
+		res.HandleFieldsPost(w, r)
+	case http.MethodPut:
+		res.HandleFieldsPut(w, r)
+	case http.MethodDelete:
+		res.HandleFieldsDelete(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+// FieldsRefreshHandler atende POST /pipedrive/admin/fields/refresh: recarrega
+// o cache de campos de todas as entidades (ou apenas de ?entity=)
+func FieldsRefreshHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	if r.Method != http.MethodPost {
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+		return
+	}
+
+	entities := make([]string, 0, len(fieldEntities))
+	if entity := r.URL.Query().Get("entity"); entity != "" {
+		if _, ok := fieldEntities[entity]; !ok {
+			utils.JSONError(w, http.StatusNotFound, fmt.Sprintf("unknown entity '%s': use deals, organizations, persons or products", entity), nil)
+			return
+		}
+		entities = append(entities, entity)
+	} else {
+		for name := range fieldEntities {
+			entities = append(entities, name)
+		}
+		sort.Strings(entities)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	c := client.NewPipedriveClient()
+	results := make(map[string]interface{}, len(entities))
+	success := 0
+
+	for _, name := range entities {
+		res := fieldEntities[name]
+		count, err := res.RefreshFields(ctx, c)
+		if err != nil {
+			results[name] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": http.StatusServiceUnavailable,
+			}
+			continue
+		}
+		entry := map[string]interface{}{"fields": count}
+		if age, ok := res.FieldsAge(); ok {
+			entry["cache_age_ms"] = age.Milliseconds()
+		}
+		results[name] = entry
+		success++
+	}
+
+	meta := utils.NewMetaItem(start, r.Header.Get(utils.HeaderXRequestID), c.BaseURL()+" (fields cache refresh)", http.StatusOK, nil)
+	utils.JSONOK(w, map[string]interface{}{
+		"status":  resource.BulkStatus(success, len(entities)),
+		"results": results,
+	}, meta)
+}
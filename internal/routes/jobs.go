@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"pipedrive_api_service/internal/jobs"
+	"pipedrive_api_service/internal/utils"
+)
+
+// JobsHandler atende GET /pipedrive/jobs: lista os jobs assíncronos, sem os
+// resultados por item
+func JobsHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	manager := jobs.GlobalManager()
+	if manager == nil {
+		utils.JSONError(w, http.StatusServiceUnavailable, "async jobs are not enabled", nil)
+		return
+	}
+	if r.Method != http.MethodGet {
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+		return
+	}
+
+	list := manager.List()
+	meta := utils.NewMetaItem(start, r.Header.Get(utils.HeaderXRequestID), "(jobs)", http.StatusOK, nil)
+	meta.Extra = &utils.ExtraMeta{TotalResults: len(list)}
+	utils.JSONOK(w, list, meta)
+}
+
+// JobHandler atende /pipedrive/jobs/{id}: GET retorna progresso e resultados,
+// DELETE cancela o job
+func JobHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	manager := jobs.GlobalManager()
+	if manager == nil {
+		utils.JSONError(w, http.StatusServiceUnavailable, "async jobs are not enabled", nil)
+		return
+	}
+
+	id := r.PathValue("id")
+	var status jobs.Status
+	var err error
+
+	switch r.Method {
+	case http.MethodGet:
+		status, err = manager.Get(id)
+	case http.MethodDelete:
+		status, err = manager.Cancel(id)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+		return
+	}
+
+	meta := utils.NewMetaItem(start, r.Header.Get(utils.HeaderXRequestID), "(job "+id+")", http.StatusOK, nil)
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		meta.Status = http.StatusNotFound
+		utils.JSONError(w, http.StatusNotFound, err.Error(), meta)
+	case errors.Is(err, jobs.ErrFinished):
+		meta.Status = http.StatusConflict
+		utils.JSONError(w, http.StatusConflict, map[string]interface{}{
+			"message": err.Error(),
+			"job":     status,
+		}, meta)
+	default:
+		utils.JSONOK(w, status, meta)
+	}
+}
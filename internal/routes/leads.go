@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+
+	"pipedrive_api_service/internal/routes/leads"
+	"pipedrive_api_service/internal/utils"
+)
+
+func LeadsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		leads.HandleGet(w, r)
+	case http.MethodPost:
+		leads.HandlePost(w, r)
+	case http.MethodPut:
+		leads.HandlePut(w, r)
+	case http.MethodDelete:
+		leads.HandleDelete(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+func LeadsConvertHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		leads.HandleConvert(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
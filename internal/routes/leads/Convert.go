@@ -0,0 +1,220 @@
+package leads
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+// LeadConvertItem descreve a conversão de um lead em deal
+type LeadConvertItem struct {
+	LeadID     string `json:"lead_id"`
+	PipelineID *int   `json:"pipeline_id,omitempty"`
+	StageID    *int   `json:"stage_id,omitempty"`
+	Title      string `json:"title,omitempty"`
+	// DeleteLead remove o lead após a conversão; por padrão ele é apenas arquivado
+	DeleteLead bool `json:"delete_lead,omitempty"`
+}
+
+// HandleConvert converte leads em deals no pipeline/etapa informados. O lead
+// é arquivado (ou removido com delete_lead=true) depois que o deal é criado.
+func HandleConvert(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	bodyRaw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "failed to read request body",
+			"detail":  err.Error(),
+		}, nil)
+		return
+	}
+	defer r.Body.Close()
+
+	var items []LeadConvertItem
+	if err := json.Unmarshal(bodyRaw, &items); err != nil {
+		var single LeadConvertItem
+		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil && single.LeadID != "" {
+			items = append(items, single)
+		}
+	}
+
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "no valid leads to convert found in body",
+			"hint":    "body must be either a single object or an array of objects with 'lead_id' and 'pipeline_id' or 'stage_id'",
+			"example_single": map[string]interface{}{
+				"lead_id":     "adf21080-0e10-11eb-879b-05d71fb426ec",
+				"pipeline_id": 1,
+				"stage_id":    3,
+			},
+		}, nil)
+		return
+	}
+
+	results := make(map[string]interface{}, len(items))
+	success := 0
+
+	for i, item := range items {
+		indexKey := fmt.Sprintf("%d", i)
+
+		res, status, err := convertLead(r.Context(), c, item)
+		if err != nil {
+			results[indexKey] = map[string]interface{}{
+				"lead_id": item.LeadID,
+				"error":   err.Error(),
+				"status":  status,
+			}
+			continue
+		}
+		results[indexKey] = res
+		success++
+	}
+
+	finalStatus := resource.BulkStatus(success, len(items))
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/leads (convert bulk)",
+		http.StatusCreated,
+		nil,
+	)
+
+	utils.JSONOK(w, map[string]interface{}{
+		"status":  finalStatus,
+		"results": results,
+	}, meta)
+}
+
+func convertLead(ctx context.Context, c *client.PipedriveClient, item LeadConvertItem) (map[string]interface{}, int, error) {
+	if !isValidLeadID(item.LeadID) {
+		return nil, http.StatusBadRequest, errors.New("invalid lead_id: must be a UUID")
+	}
+	if item.PipelineID == nil && item.StageID == nil {
+		return nil, http.StatusBadRequest, errors.New("one of 'pipeline_id' or 'stage_id' is required")
+	}
+
+	lead, status, err := fetchLeadAsMap(ctx, c, item.LeadID)
+	if err != nil {
+		return nil, status, fmt.Errorf("fetch lead: %w", err)
+	}
+	if archived, _ := lead["is_archived"].(bool); archived {
+		return nil, http.StatusConflict, errors.New("lead is archived and cannot be converted")
+	}
+
+	payload := buildDealFromLead(lead, item)
+	bodyBytes, _ := json.Marshal(payload)
+
+	reqCtx, cancel := context.WithTimeout(ctx, resource.DefaultTimeout)
+	resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPost, "/deals", nil, bytes.NewReader(bodyBytes))
+	cancel()
+	if err != nil || resp == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("failed to reach upstream Pipedrive: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+	if resp.StatusCode >= 400 {
+		return nil, resp.StatusCode, fmt.Errorf("create deal: %s", resource.ExtractUpstreamError(parsed))
+	}
+
+	result := map[string]interface{}{
+		"lead_id": item.LeadID,
+		"deal":    parsed["data"],
+	}
+
+	// O deal já existe: falhas ao arquivar/remover o lead não desfazem a conversão
+	if item.DeleteLead {
+		if _, err := closeLead(ctx, c, item.LeadID, utils.HTTPDelete, nil); err != nil {
+			result["lead_warning"] = fmt.Sprintf("deal created but lead could not be deleted: %v", err)
+		} else {
+			result["lead_deleted"] = true
+		}
+	} else {
+		archive, _ := json.Marshal(map[string]interface{}{"is_archived": true})
+		if _, err := closeLead(ctx, c, item.LeadID, utils.HTTPPatch, archive); err != nil {
+			result["lead_warning"] = fmt.Sprintf("deal created but lead could not be archived: %v", err)
+		} else {
+			result["lead_archived"] = true
+		}
+	}
+
+	return result, resp.StatusCode, nil
+}
+
+// buildDealFromLead copia título, valor, vínculos, dono e campos customizados do lead
+func buildDealFromLead(lead map[string]interface{}, item LeadConvertItem) map[string]interface{} {
+	payload := map[string]interface{}{
+		"title": lead["title"],
+	}
+	if item.Title != "" {
+		payload["title"] = item.Title
+	}
+	if item.PipelineID != nil {
+		payload["pipeline_id"] = *item.PipelineID
+	}
+	if item.StageID != nil {
+		payload["stage_id"] = *item.StageID
+	}
+	if value, ok := lead["value"].(map[string]interface{}); ok {
+		payload["value"] = value["amount"]
+		payload["currency"] = value["currency"]
+	}
+	if v, ok := lead["person_id"]; ok && v != nil {
+		payload["person_id"] = v
+	}
+	if v, ok := lead["organization_id"]; ok && v != nil {
+		payload["org_id"] = v
+	}
+	if v, ok := lead["owner_id"]; ok && v != nil {
+		payload["user_id"] = v
+	}
+	if v, ok := lead["expected_close_date"]; ok && v != nil {
+		payload["expected_close_date"] = v
+	}
+	// Leads compartilham os campos customizados de deals
+	for k, v := range lead {
+		if resource.IsCustomFieldKey(k) && v != nil {
+			payload[k] = v
+		}
+	}
+	return payload
+}
+
+func closeLead(ctx context.Context, c *client.PipedriveClient, id string, method utils.HTTPMethod, body []byte) (int, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, resource.DefaultTimeout)
+	defer cancel()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	resp, respBody, _, err := c.DoWithBody(reqCtx, method, "/leads/"+id, nil, reader)
+	if err != nil {
+		return http.StatusServiceUnavailable, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var parsed map[string]interface{}
+		_ = json.Unmarshal(respBody, &parsed)
+		return resp.StatusCode, errors.New(resource.ExtractUpstreamError(parsed))
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,130 @@
+package leads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+// HandleDelete removes one or multiple leads by UUID
+func HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	bodyRaw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "failed to read request body",
+			"detail":  err.Error(),
+		}, nil)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(bodyRaw) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message":        "request body is empty",
+			"hint":           "provide at least one lead ID to delete",
+			"example_single": map[string]string{"id": "adf21080-0e10-11eb-879b-05d71fb426ec"},
+			"example_bulk":   []string{"adf21080-0e10-11eb-879b-05d71fb426ec", "b1c2d3e4-0e10-11eb-879b-05d71fb426ec"},
+		}, nil)
+		return
+	}
+
+	// Suporta: ["uuid", ...] ou { "id": "uuid" }
+	var ids []string
+	if err := json.Unmarshal(bodyRaw, &ids); err != nil {
+		var single map[string]interface{}
+		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil {
+			if idVal, ok := single["id"].(string); ok {
+				ids = append(ids, idVal)
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "no valid lead IDs found in body",
+			"hint":    "body must contain a UUID 'id' or a list of UUIDs",
+		}, nil)
+		return
+	}
+
+	results := make(map[string]interface{}, len(ids))
+	successCount := 0
+
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if !isValidLeadID(id) {
+			results[id] = map[string]interface{}{
+				"error":  "invalid id: must be a UUID",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		reqCtx, cancel := context.WithTimeout(r.Context(), resource.DefaultTimeout)
+		resp, body, _, err := c.Do(reqCtx, utils.HTTPDelete, "/leads/"+id, nil)
+		cancel()
+
+		if err != nil || resp == nil {
+			results[id] = map[string]interface{}{
+				"error":  fmt.Sprintf("failed to reach upstream Pipedrive: %v", err),
+				"status": http.StatusServiceUnavailable,
+			}
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			var parsed map[string]interface{}
+			_ = json.Unmarshal(body, &parsed)
+			results[id] = map[string]interface{}{
+				"error":  fmt.Sprintf("upstream returned %d", resp.StatusCode),
+				"status": resp.StatusCode,
+				"detail": parsed["error"],
+			}
+			continue
+		}
+
+		results[id] = map[string]interface{}{
+			"success": true,
+			"deleted": id,
+		}
+		successCount++
+	}
+
+	finalStatus := resource.BulkStatus(successCount, len(ids))
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/leads (delete bulk)",
+		http.StatusOK,
+		nil,
+	)
+
+	summary := map[string]int{
+		"requested": len(ids),
+		"deleted":   successCount,
+		"failed":    len(ids) - successCount,
+	}
+
+	utils.JSONOK(w, map[string]interface{}{
+		"status":  finalStatus,
+		"summary": summary,
+		"results": results,
+	}, meta)
+}
@@ -0,0 +1,225 @@
+package leads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+const LeadsPageLimit = 500
+
+// IDs de leads são UUIDs; o parsing numérico usado em deals/organizações não se aplica
+var leadIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Filtros resolvidos pelo próprio Pipedrive no GET /leads
+var leadUpstreamFilters = []string{"archived_status", "owner_id", "person_id", "organization_id", "filter_id", "sort"}
+
+func isValidLeadID(id string) bool {
+	return leadIDPattern.MatchString(strings.TrimSpace(id))
+}
+
+func fetchLeadAsMap(ctx context.Context, c *client.PipedriveClient, id string) (map[string]interface{}, int, error) {
+	if !isValidLeadID(id) {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid lead id '%s': must be a UUID", id)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, resource.DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.Do(reqCtx, utils.HTTPGet, "/leads/"+strings.TrimSpace(id), nil)
+	if err != nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("get: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode, fmt.Errorf("upstream status %s", resp.Status)
+	}
+
+	var parsed struct {
+		Success bool                   `json:"success"`
+		Data    map[string]interface{} `json:"data"`
+		Error   interface{}            `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("parse upstream response: %w", err)
+	}
+	return parsed.Data, http.StatusOK, nil
+}
+
+func listLeads(ctx context.Context, c *client.PipedriveClient, query url.Values, finalResponse *models.LeadsResponse) (*utils.RateLimitInfo, int, error) {
+	finalResponse.Data = []models.Lead{}
+
+	rateLimitInfo := &utils.RateLimitInfo{}
+	upstreamStatus := http.StatusOK
+
+	upstreamQuery := url.Values{}
+	for _, key := range leadUpstreamFilters {
+		if v := query.Get(key); v != "" {
+			upstreamQuery.Set(key, v)
+		}
+	}
+
+	pageFilter := query.Get("page")
+	isPageAll := pageFilter == "all"
+	start := 0
+	if pageFilter != "" && !isPageAll {
+		page, err := strconv.Atoi(pageFilter)
+		if err == nil && page > 0 {
+			start = (page - 1) * LeadsPageLimit
+		}
+	}
+
+	limit := query.Get("limit")
+	if limit == "" {
+		limit = fmt.Sprintf("%d", LeadsPageLimit)
+	}
+	upstreamQuery.Set("limit", limit)
+
+	for {
+		if ctx.Err() != nil {
+			return rateLimitInfo, http.StatusGatewayTimeout, fmt.Errorf("gateway process cancelled: %w", ctx.Err())
+		}
+
+		currentQuery := make(url.Values)
+		for k, v := range upstreamQuery {
+			currentQuery[k] = v
+		}
+		currentQuery.Set("start", fmt.Sprintf("%d", start))
+
+		resp, body, rate, err := c.Do(ctx, utils.HTTPGet, "/leads", currentQuery)
+		if err != nil {
+			return rate, http.StatusServiceUnavailable, err
+		}
+
+		if rate != nil {
+			rateLimitInfo = rate
+		}
+		upstreamStatus = resp.StatusCode
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return rateLimitInfo, upstreamStatus, fmt.Errorf("upstream returned status: %s", resp.Status)
+		}
+
+		tempResponse := models.LeadsResponse{}
+		if err := json.Unmarshal(body, &tempResponse); err != nil {
+			resp.Body.Close()
+			return rateLimitInfo, http.StatusInternalServerError, fmt.Errorf("failed to parse upstream response: %w", err)
+		}
+		resp.Body.Close()
+
+		finalResponse.Data = append(finalResponse.Data, tempResponse.Data...)
+
+		if !isPageAll || !tempResponse.AdditionalData.Pagination.MoreItemsInCollection {
+			break
+		}
+
+		start = tempResponse.AdditionalData.Pagination.NextStart
+	}
+
+	return rateLimitInfo, upstreamStatus, nil
+}
+
+func HandleGet(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	id := query.Get("id")
+
+	fieldsQuery := query.Get("fields")
+	fieldsAll := strings.EqualFold(fieldsQuery, "all")
+
+	c := client.NewPipedriveClient()
+	start := time.Now()
+
+	if id != "" {
+		ids := strings.Split(id, ",")
+		results := make([]map[string]interface{}, 0, len(ids))
+		upstreamStatus := http.StatusOK
+
+		for _, leadID := range ids {
+			if strings.TrimSpace(leadID) == "" {
+				continue
+			}
+			lead, status, err := fetchLeadAsMap(r.Context(), c, leadID)
+			if err != nil {
+				upstreamStatus = status
+				continue
+			}
+			results = append(results, lead)
+		}
+
+		meta := utils.NewMetaItem(
+			start,
+			r.Header.Get(utils.HeaderXRequestID),
+			c.BaseURL()+fmt.Sprintf("/leads/details/{%d IDs}", len(ids)),
+			http.StatusOK,
+			nil,
+		)
+
+		if len(results) == 0 {
+			if upstreamStatus == http.StatusOK {
+				upstreamStatus = http.StatusNotFound
+			}
+			meta.Status = upstreamStatus
+			utils.JSONError(w, upstreamStatus, "no leads found for the provided IDs", meta)
+			return
+		}
+
+		if fieldsQuery != "" && !fieldsAll {
+			filtered, filterErr := utils.FilterMapSliceByFields(results, fieldsQuery)
+			if filterErr != nil {
+				meta.Status = http.StatusInternalServerError
+				utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to filter fields: %v", filterErr), meta)
+				return
+			}
+			results = filtered
+		}
+
+		meta.Extra = &utils.ExtraMeta{TotalResults: len(results)}
+		utils.JSONOK(w, results, meta)
+		return
+	}
+
+	envelope := &models.LeadsResponse{}
+	rate, upstreamStatus, err := listLeads(r.Context(), c, query, envelope)
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/leads",
+		upstreamStatus,
+		rate,
+	)
+
+	if err != nil {
+		meta.Status = upstreamStatus
+		utils.JSONError(w, upstreamStatus, err.Error(), meta)
+		return
+	}
+
+	localQuery := r.URL.Query()
+	for _, key := range append([]string{"page", "limit"}, leadUpstreamFilters...) {
+		localQuery.Del(key)
+	}
+	filtered, filterErr := utils.FilterSliceByQuery(envelope.GetDataSlice(), localQuery)
+	if filterErr != nil {
+		meta.Status = http.StatusInternalServerError
+		utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply local filter: %v", filterErr), meta)
+		return
+	}
+	envelope.SetDataSlice(filtered)
+
+	meta.Extra = &utils.ExtraMeta{TotalResults: len(envelope.Data)}
+	utils.JSONOK(w, envelope, meta)
+}
@@ -1,4 +1,4 @@
-package deals
+package leads
 
 import (
 	"bytes"
@@ -11,24 +11,29 @@ import (
 	"time"
 
 	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
 	"pipedrive_api_service/internal/utils"
 )
 
-type DealCreateItem struct {
-	Title        string                 `json:"title"`
-	Value        *float64               `json:"value,omitempty"`
-	Currency     string                 `json:"currency,omitempty"`
-	UserID       *int                   `json:"user_id,omitempty"`
-	PipelineID   *int                   `json:"pipeline_id,omitempty"`
-	StageID      *int                   `json:"stage_id,omitempty"`
-	OrgID        *int                   `json:"org_id,omitempty"`
-	PersonID     *int                   `json:"person_id,omitempty"`
-	VisibleTo    *int                   `json:"visible_to,omitempty"`
-	Status       string                 `json:"status,omitempty"`
-	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+type LeadCreateItem struct {
+	Title             string                 `json:"title"`
+	OwnerID           *int                   `json:"owner_id,omitempty"`
+	PersonID          *int                   `json:"person_id,omitempty"`
+	OrganizationID    *int                   `json:"organization_id,omitempty"`
+	Value             *models.LeadValue      `json:"value,omitempty"`
+	ExpectedCloseDate string                 `json:"expected_close_date,omitempty"`
+	LabelIDs          []string               `json:"label_ids,omitempty"`
+	VisibleTo         string                 `json:"visible_to,omitempty"`
+	WasSeen           *bool                  `json:"was_seen,omitempty"`
+	CustomFields      map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 func HandlePost(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
 	start := time.Now()
 	c := client.NewPipedriveClient()
 
@@ -45,27 +50,28 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 	if len(bodyRaw) == 0 {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
 			"message": "request body is empty",
-			"hint":    "provide at least one deal to create",
+			"hint":    "provide at least one lead to create",
 		}, nil)
 		return
 	}
 
-	var items []DealCreateItem
-
+	var items []LeadCreateItem
 	if err := json.Unmarshal(bodyRaw, &items); err != nil {
-		var single DealCreateItem
+		var single LeadCreateItem
 		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil && strings.TrimSpace(single.Title) != "" {
 			items = append(items, single)
 		} else {
 			utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
 				"message": "invalid JSON format",
 				"hint":    "body must be either a single object or an array of objects",
-				"example_single": map[string]string{
-					"title": "New Sales Opportunity",
+				"example_single": map[string]interface{}{
+					"title":     "Contato via formulário do site",
+					"person_id": 42,
+					"value":     map[string]interface{}{"amount": 5000, "currency": "BRL"},
 				},
-				"example_bulk": []map[string]string{
-					{"title": "Deal A"},
-					{"title": "Deal B"},
+				"example_bulk": []map[string]interface{}{
+					{"title": "Lead A", "organization_id": 10},
+					{"title": "Lead B", "person_id": 20},
 				},
 			}, nil)
 			return
@@ -74,7 +80,7 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 
 	if len(items) == 0 {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
-			"message": "no valid deals found in body",
+			"message": "no valid leads found in body",
 			"hint":    "check your JSON structure and required fields",
 		}, nil)
 		return
@@ -93,45 +99,57 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
+		if item.PersonID == nil && item.OrganizationID == nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  "one of 'person_id' or 'organization_id' is required",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
 
 		payload := map[string]interface{}{
 			"title": item.Title,
 		}
-		if item.Value != nil {
-			payload["value"] = *item.Value
-		}
-		if item.Currency != "" {
-			payload["currency"] = item.Currency
+		if item.OwnerID != nil {
+			payload["owner_id"] = *item.OwnerID
 		}
-		if item.UserID != nil {
-			payload["user_id"] = *item.UserID
+		if item.PersonID != nil {
+			payload["person_id"] = *item.PersonID
 		}
-		if item.PipelineID != nil {
-			payload["pipeline_id"] = *item.PipelineID
+		if item.OrganizationID != nil {
+			payload["organization_id"] = *item.OrganizationID
 		}
-		if item.StageID != nil {
-			payload["stage_id"] = *item.StageID
+		if item.Value != nil {
+			payload["value"] = item.Value
 		}
-		if item.OrgID != nil {
-			payload["org_id"] = *item.OrgID
+		if item.ExpectedCloseDate != "" {
+			payload["expected_close_date"] = item.ExpectedCloseDate
 		}
-		if item.PersonID != nil {
-			payload["person_id"] = *item.PersonID
+		if len(item.LabelIDs) > 0 {
+			payload["label_ids"] = item.LabelIDs
 		}
-		if item.VisibleTo != nil {
-			payload["visible_to"] = *item.VisibleTo
+		if item.VisibleTo != "" {
+			payload["visible_to"] = item.VisibleTo
 		}
-		if item.Status != "" {
-			payload["status"] = item.Status
+		if item.WasSeen != nil {
+			payload["was_seen"] = *item.WasSeen
 		}
 		for k, v := range item.CustomFields {
 			payload[k] = v
 		}
 
+		if validationErr := validateLead(payload); validationErr != nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  validationErr.Error(),
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
 		bodyBytes, _ := json.Marshal(payload)
 
-		reqCtx, cancel := context.WithTimeout(r.Context(), 8*time.Second)
-		resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPost, "/deals", nil, bytes.NewReader(bodyBytes))
+		reqCtx, cancel := context.WithTimeout(r.Context(), resource.DefaultTimeout)
+		resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPost, "/leads", nil, bytes.NewReader(bodyBytes))
 		cancel()
 
 		if err != nil || resp == nil {
@@ -141,11 +159,13 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
-		defer resp.Body.Close()
+		resp.Body.Close()
 
 		if resp.StatusCode >= 400 {
+			var parsed map[string]interface{}
+			_ = json.Unmarshal(body, &parsed)
 			results[indexKey] = map[string]interface{}{
-				"error":  fmt.Sprintf("upstream returned %d", resp.StatusCode),
+				"error":  resource.ExtractUpstreamError(parsed),
 				"status": resp.StatusCode,
 			}
 			continue
@@ -171,17 +191,12 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	finalStatus := "success"
-	if success == 0 {
-		finalStatus = "failure"
-	} else if success < len(items) {
-		finalStatus = "partial_failure"
-	}
+	finalStatus := resource.BulkStatus(success, len(items))
 
 	meta := utils.NewMetaItem(
 		start,
 		r.Header.Get(utils.HeaderXRequestID),
-		c.BaseURL()+"/deals (create bulk)",
+		c.BaseURL()+"/leads (create bulk)",
 		http.StatusCreated,
 		nil,
 	)
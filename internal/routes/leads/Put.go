@@ -0,0 +1,188 @@
+package leads
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+// LeadUpdateItem usa ID string (UUID), diferente dos demais recursos
+type LeadUpdateItem struct {
+	Verbose bool                   `json:"verbose,omitempty"`
+	ID      string                 `json:"id"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Campos bloqueados para edição direta
+var readOnlyFields = map[string]bool{
+	"id":          true,
+	"creator_id":  true,
+	"source_name": true,
+	"add_time":    true,
+	"update_time": true,
+}
+
+func HandlePut(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+
+	var items []LeadUpdateItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid JSON body", nil)
+		return
+	}
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, "empty update list", nil)
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	results := make(map[string]interface{}, len(items))
+	success := 0
+
+	for i, it := range items {
+		idKey := strings.TrimSpace(it.ID)
+		if idKey == "" {
+			idKey = fmt.Sprintf("%d", i)
+		}
+
+		if !isValidLeadID(it.ID) {
+			results[idKey] = map[string]interface{}{
+				"error":  "invalid id: must be a UUID",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if len(it.Fields) == 0 {
+			results[idKey] = map[string]interface{}{
+				"error":  "fields required for update",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if validationErr := validateFields(it.Fields); validationErr != nil {
+			results[idKey] = map[string]interface{}{
+				"error":  validationErr.Error(),
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		res, status, err := doUpdate(r.Context(), c, idKey, it.Fields, it.Verbose)
+		if err != nil {
+			results[idKey] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": status,
+			}
+			continue
+		}
+		results[idKey] = res
+		success++
+	}
+
+	finalStatus := resource.BulkStatus(success, len(items))
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/leads (bulk)",
+		http.StatusMultiStatus,
+		nil,
+	)
+
+	utils.JSONOK(w, models.BulkUpdateResult{
+		Status:  finalStatus,
+		Results: results,
+	}, meta)
+}
+
+// --- Helpers ---
+
+// doUpdate atualiza um lead. O Pipedrive usa PATCH (e não PUT) para leads.
+func doUpdate(ctx context.Context, c *client.PipedriveClient, id string, fields map[string]interface{}, verbose bool) (interface{}, int, error) {
+	bodyBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("marshal fields: %w", err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, resource.DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPatch, "/leads/"+id, nil, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+
+	if resp.StatusCode >= 400 {
+		return parsed, resp.StatusCode, errors.New(resource.ExtractUpstreamError(parsed))
+	}
+
+	if !verbose {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		return map[string]interface{}{
+			"success":        true,
+			"fields_altered": keys,
+		}, resp.StatusCode, nil
+	}
+	return parsed["data"], resp.StatusCode, nil
+}
+
+func validateFields(fields map[string]interface{}) error {
+	for k := range fields {
+		if readOnlyFields[k] {
+			return fmt.Errorf("field '%s' is read-only and cannot be modified", k)
+		}
+	}
+	return validateLead(fields)
+}
+
+// validateLead valida os campos com formato próprio dos leads
+func validateLead(fields map[string]interface{}) error {
+	if v, ok := fields["title"]; ok && strings.TrimSpace(fmt.Sprint(v)) == "" {
+		return fmt.Errorf("field 'title' cannot be empty")
+	}
+	if v, ok := fields["expected_close_date"]; ok && v != nil && v != "" {
+		if _, err := time.Parse("2006-01-02", fmt.Sprint(v)); err != nil {
+			return fmt.Errorf("invalid value for 'expected_close_date': expected YYYY-MM-DD")
+		}
+	}
+	if v, ok := fields["value"]; ok && v != nil {
+		switch val := v.(type) {
+		case *models.LeadValue:
+		case map[string]interface{}:
+			if _, hasAmount := val["amount"]; !hasAmount {
+				return fmt.Errorf("invalid value for 'value': must be an object {amount, currency}")
+			}
+		default:
+			return fmt.Errorf("invalid value for 'value': must be an object {amount, currency}")
+		}
+	}
+	if v, ok := fields["label_ids"]; ok && v != nil {
+		switch v.(type) {
+		case []string, []interface{}:
+		default:
+			return fmt.Errorf("invalid value for 'label_ids': must be a list of label UUIDs")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/routes/notes"
+	"pipedrive_api_service/internal/utils"
+)
+
+const NotesPageLimit = 500
+
+// Vínculos e filtros resolvidos pelo próprio Pipedrive no GET /notes
+var noteUpstreamFilters = []string{"deal_id", "person_id", "org_id", "lead_id", "user_id", "start_date", "end_date", "sort"}
+
+func notesUpstreamCall(ctx context.Context, c *client.PipedriveClient, query url.Values, dataContainer interface{}) (*utils.RateLimitInfo, int, error) {
+	finalResponse := dataContainer.(*models.NotesResponse)
+	finalResponse.Data = []models.Note{}
+
+	rateLimitInfo := &utils.RateLimitInfo{}
+	upstreamStatus := http.StatusOK
+
+	upstreamQuery := url.Values{}
+	for _, key := range noteUpstreamFilters {
+		if v := query.Get(key); v != "" {
+			upstreamQuery.Set(key, v)
+		}
+	}
+
+	pageFilter := query.Get("page")
+	isPageAll := pageFilter == "all"
+	start := 0
+	if pageFilter != "" && !isPageAll {
+		page, err := strconv.Atoi(pageFilter)
+		if err == nil && page > 0 {
+			start = (page - 1) * NotesPageLimit
+		}
+	}
+
+	limit := query.Get("limit")
+	if limit == "" {
+		limit = fmt.Sprintf("%d", NotesPageLimit)
+	}
+	upstreamQuery.Set("limit", limit)
+
+	for {
+		if ctx.Err() != nil {
+			return rateLimitInfo, http.StatusGatewayTimeout, fmt.Errorf("gateway process cancelled: %w", ctx.Err())
+		}
+
+		currentQuery := make(url.Values)
+		for k, v := range upstreamQuery {
+			currentQuery[k] = v
+		}
+		currentQuery.Set("start", fmt.Sprintf("%d", start))
+
+		resp, body, rate, err := c.Do(ctx, utils.HTTPGet, "/notes", currentQuery)
+		if err != nil {
+			return rate, http.StatusServiceUnavailable, err
+		}
+
+		if rate != nil {
+			rateLimitInfo = rate
+		}
+		upstreamStatus = resp.StatusCode
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return rateLimitInfo, upstreamStatus, fmt.Errorf("upstream returned status: %s", resp.Status)
+		}
+
+		tempResponse := models.NotesResponse{}
+		if err := json.Unmarshal(body, &tempResponse); err != nil {
+			resp.Body.Close()
+			return rateLimitInfo, http.StatusInternalServerError, fmt.Errorf("failed to parse upstream response: %w", err)
+		}
+		resp.Body.Close()
+
+		finalResponse.Data = append(finalResponse.Data, tempResponse.Data...)
+
+		if !isPageAll || !tempResponse.AdditionalData.Pagination.MoreItemsInCollection {
+			break
+		}
+
+		start = tempResponse.AdditionalData.Pagination.NextStart
+	}
+
+	return rateLimitInfo, upstreamStatus, nil
+}
+
+func NotesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		envelope := &models.NotesResponse{}
+		upstreamParams := append([]string{"page", "limit"}, noteUpstreamFilters...)
+		HandlerWrapper(notesUpstreamCall, envelope, "/notes", upstreamParams...)(w, r)
+	case http.MethodPost:
+		notes.HandlePost(w, r)
+	case http.MethodPut:
+		notes.HandlePut(w, r)
+	case http.MethodDelete:
+		notes.HandleDelete(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
@@ -1,4 +1,4 @@
-package deals
+package notes
 
 import (
 	"context"
@@ -10,11 +10,16 @@ import (
 	"time"
 
 	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/resource"
 	"pipedrive_api_service/internal/utils"
 )
 
-// HandleDelete removes one or multiple deals by ID
+// HandleDelete removes one or multiple notes by ID
 func HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
 	start := time.Now()
 	c := client.NewPipedriveClient()
 
@@ -31,7 +36,7 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 	if len(bodyRaw) == 0 {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
 			"message":        "request body is empty",
-			"hint":           "provide at least one deal ID to delete",
+			"hint":           "provide at least one note ID to delete",
 			"example_single": map[string]int{"id": 123},
 			"example_bulk":   []int{123, 124, 125},
 		}, nil)
@@ -60,7 +65,7 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 
 	if len(ids) == 0 {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
-			"message": "no valid deal IDs found in body",
+			"message": "no valid note IDs found in body",
 			"hint":    "body must contain a numeric 'id' or a list of IDs",
 		}, nil)
 		return
@@ -79,8 +84,8 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		path := fmt.Sprintf("/deals/%d", id)
-		reqCtx, cancel := context.WithTimeout(r.Context(), 8*time.Second)
+		path := fmt.Sprintf("/notes/%d", id)
+		reqCtx, cancel := context.WithTimeout(r.Context(), resource.DefaultTimeout)
 		resp, body, _, err := c.Do(reqCtx, utils.HTTPDelete, path, nil)
 		cancel()
 
@@ -91,7 +96,7 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
-		defer resp.Body.Close()
+		resp.Body.Close()
 
 		if resp.StatusCode >= 400 {
 			var parsed map[string]interface{}
@@ -111,17 +116,12 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 		successCount++
 	}
 
-	finalStatus := "success"
-	if successCount == 0 {
-		finalStatus = "failure"
-	} else if successCount < len(ids) {
-		finalStatus = "partial_failure"
-	}
+	finalStatus := resource.BulkStatus(successCount, len(ids))
 
 	meta := utils.NewMetaItem(
 		start,
 		r.Header.Get(utils.HeaderXRequestID),
-		c.BaseURL()+"/deals (delete bulk)",
+		c.BaseURL()+"/notes (delete bulk)",
 		http.StatusOK,
 		nil,
 	)
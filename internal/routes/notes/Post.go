@@ -0,0 +1,205 @@
+package notes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+// NoteCreateItem representa uma anotação a ser criada. O 'content' aceita HTML
+// (e.g. <p>, <b>, <ul>) e deve estar vinculado a ao menos um deal, pessoa,
+// organização ou lead.
+type NoteCreateItem struct {
+	Content                  string `json:"content"`
+	DealID                   *int   `json:"deal_id,omitempty"`
+	PersonID                 *int   `json:"person_id,omitempty"`
+	OrgID                    *int   `json:"org_id,omitempty"`
+	LeadID                   string `json:"lead_id,omitempty"`
+	UserID                   *int   `json:"user_id,omitempty"`
+	AddTime                  string `json:"add_time,omitempty"`
+	PinnedToDealFlag         *bool  `json:"pinned_to_deal_flag,omitempty"`
+	PinnedToPersonFlag       *bool  `json:"pinned_to_person_flag,omitempty"`
+	PinnedToOrganizationFlag *bool  `json:"pinned_to_organization_flag,omitempty"`
+	PinnedToLeadFlag         *bool  `json:"pinned_to_lead_flag,omitempty"`
+}
+
+func HandlePost(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	bodyRaw, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "failed to read request body",
+			"detail":  err.Error(),
+		}, nil)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(bodyRaw) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "request body is empty",
+			"hint":    "provide at least one note to create",
+		}, nil)
+		return
+	}
+
+	var items []NoteCreateItem
+	if err := json.Unmarshal(bodyRaw, &items); err != nil {
+		var single NoteCreateItem
+		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil && strings.TrimSpace(single.Content) != "" {
+			items = append(items, single)
+		} else {
+			utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+				"message": "invalid JSON format",
+				"hint":    "body must be either a single object or an array of objects",
+				"example_single": map[string]interface{}{
+					"content": "<p><b>Resumo da ligação</b></p><ul><li>Cliente pediu proposta</li></ul>",
+					"deal_id": 123,
+				},
+				"example_bulk": []map[string]interface{}{
+					{"content": "<p>Nota A</p>", "org_id": 10},
+					{"content": "<p>Nota B</p>", "person_id": 20},
+				},
+			}, nil)
+			return
+		}
+	}
+
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
+			"message": "no valid notes found in body",
+			"hint":    "check your JSON structure and required fields",
+		}, nil)
+		return
+	}
+
+	results := make(map[string]interface{}, len(items))
+	success := 0
+
+	for i, item := range items {
+		indexKey := fmt.Sprintf("%d", i)
+
+		if strings.TrimSpace(item.Content) == "" {
+			results[indexKey] = map[string]interface{}{
+				"error":  "field 'content' is required",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if item.DealID == nil && item.PersonID == nil && item.OrgID == nil && item.LeadID == "" {
+			results[indexKey] = map[string]interface{}{
+				"error":  "one of 'deal_id', 'person_id', 'org_id' or 'lead_id' is required",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		payload := map[string]interface{}{
+			"content": item.Content,
+		}
+		if item.DealID != nil {
+			payload["deal_id"] = *item.DealID
+		}
+		if item.PersonID != nil {
+			payload["person_id"] = *item.PersonID
+		}
+		if item.OrgID != nil {
+			payload["org_id"] = *item.OrgID
+		}
+		if item.LeadID != "" {
+			payload["lead_id"] = item.LeadID
+		}
+		if item.UserID != nil {
+			payload["user_id"] = *item.UserID
+		}
+		if item.AddTime != "" {
+			payload["add_time"] = item.AddTime
+		}
+		if item.PinnedToDealFlag != nil {
+			payload["pinned_to_deal_flag"] = pinnedFlag(*item.PinnedToDealFlag)
+		}
+		if item.PinnedToPersonFlag != nil {
+			payload["pinned_to_person_flag"] = pinnedFlag(*item.PinnedToPersonFlag)
+		}
+		if item.PinnedToOrganizationFlag != nil {
+			payload["pinned_to_organization_flag"] = pinnedFlag(*item.PinnedToOrganizationFlag)
+		}
+		if item.PinnedToLeadFlag != nil {
+			payload["pinned_to_lead_flag"] = pinnedFlag(*item.PinnedToLeadFlag)
+		}
+
+		bodyBytes, _ := json.Marshal(payload)
+
+		reqCtx, cancel := context.WithTimeout(r.Context(), resource.DefaultTimeout)
+		resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPost, "/notes", nil, bytes.NewReader(bodyBytes))
+		cancel()
+
+		if err != nil || resp == nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  fmt.Sprintf("failed to reach upstream Pipedrive: %v", err),
+				"status": http.StatusServiceUnavailable,
+			}
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			var parsed map[string]interface{}
+			_ = json.Unmarshal(body, &parsed)
+			results[indexKey] = map[string]interface{}{
+				"error":  resource.ExtractUpstreamError(parsed),
+				"status": resp.StatusCode,
+			}
+			continue
+		}
+
+		var parsed map[string]interface{}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  fmt.Sprintf("unable to parse upstream response: %v", err),
+				"status": http.StatusInternalServerError,
+			}
+			continue
+		}
+
+		if data, ok := parsed["data"]; ok {
+			results[indexKey] = data
+			success++
+		} else {
+			results[indexKey] = map[string]interface{}{
+				"warning": "upstream response missing 'data' field",
+				"status":  resp.StatusCode,
+			}
+		}
+	}
+
+	finalStatus := resource.BulkStatus(success, len(items))
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/notes (create bulk)",
+		http.StatusCreated,
+		nil,
+	)
+
+	utils.JSONOK(w, map[string]interface{}{
+		"status":  finalStatus,
+		"results": results,
+	}, meta)
+}
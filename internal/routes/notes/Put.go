@@ -0,0 +1,180 @@
+package notes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+type NoteUpdateItem struct {
+	Verbose bool                   `json:"verbose,omitempty"`
+	ID      int                    `json:"id"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Campos bloqueados para edição direta
+var readOnlyFields = map[string]bool{
+	"user_id":     true,
+	"update_time": true,
+	"active_flag": true,
+}
+
+// Flags de fixação aceitas como booleano e enviadas como 0/1
+var pinnedFlagFields = map[string]bool{
+	"pinned_to_deal_flag":         true,
+	"pinned_to_person_flag":       true,
+	"pinned_to_organization_flag": true,
+	"pinned_to_lead_flag":         true,
+}
+
+func HandlePut(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+
+	var items []NoteUpdateItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid JSON body", nil)
+		return
+	}
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, "empty update list", nil)
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	results := make(map[string]interface{}, len(items))
+	success := 0
+
+	for _, it := range items {
+		idStr := strconv.Itoa(it.ID)
+
+		if it.ID <= 0 {
+			results[idStr] = map[string]interface{}{
+				"error":  "invalid id",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if len(it.Fields) == 0 {
+			results[idStr] = map[string]interface{}{
+				"error":  "fields required for update",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if validationErr := validateFields(it.Fields); validationErr != nil {
+			results[idStr] = map[string]interface{}{
+				"error":  validationErr.Error(),
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		res, status, err := doUpdate(r.Context(), c, it.ID, it.Fields, it.Verbose)
+		if err != nil {
+			results[idStr] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": status,
+			}
+			continue
+		}
+		results[idStr] = res
+		success++
+	}
+
+	finalStatus := resource.BulkStatus(success, len(items))
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/notes (bulk)",
+		http.StatusMultiStatus,
+		nil,
+	)
+
+	utils.JSONOK(w, models.BulkUpdateResult{
+		Status:  finalStatus,
+		Results: results,
+	}, meta)
+}
+
+// --- Helpers ---
+
+func doUpdate(ctx context.Context, c *client.PipedriveClient, id int, fields map[string]interface{}, verbose bool) (interface{}, int, error) {
+	for k, v := range fields {
+		if pinned, ok := v.(bool); ok && pinnedFlagFields[k] {
+			fields[k] = pinnedFlag(pinned)
+		}
+	}
+
+	bodyBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("marshal fields: %w", err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, resource.DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPut, "/notes/"+strconv.Itoa(id), nil, bytes.NewReader(bodyBytes))
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		return nil, status, fmt.Errorf("update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+
+	if resp.StatusCode >= 400 {
+		return parsed, resp.StatusCode, errors.New(resource.ExtractUpstreamError(parsed))
+	}
+
+	if !verbose {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		return map[string]interface{}{
+			"success":        true,
+			"fields_altered": keys,
+		}, resp.StatusCode, nil
+	}
+	return parsed["data"], resp.StatusCode, nil
+}
+
+// pinnedFlag converte o booleano para o formato 0/1 esperado pelo Pipedrive
+func pinnedFlag(pinned bool) int {
+	if pinned {
+		return 1
+	}
+	return 0
+}
+
+func validateFields(fields map[string]interface{}) error {
+	for k := range fields {
+		if readOnlyFields[k] {
+			return fmt.Errorf("field '%s' is read-only and cannot be modified", k)
+		}
+	}
+	if content, ok := fields["content"]; ok && strings.TrimSpace(fmt.Sprint(content)) == "" {
+		return fmt.Errorf("field 'content' cannot be empty")
+	}
+	return nil
+}
@@ -10,13 +10,31 @@ import (
 func OrganizationsHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		org.HandleGet(w, r)
+		org.Resource.HandleGet(w, r)
 	case http.MethodPut:
-		org.HandlePut(w, r)
+		org.Resource.HandlePut(w, r)
+	case http.MethodPatch:
+		org.Resource.HandlePatch(w, r)
 	case http.MethodPost:
-		org.HandlePost(w, r)
+		org.Resource.HandlePost(w, r)
 	case http.MethodDelete:
-		org.HandleDelete(w, r)
+		org.Resource.HandleDelete(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+// OrganizationHandler atende /pipedrive/organizations/{id}
+func OrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		org.Resource.HandleGetByID(w, r)
+	case http.MethodPut:
+		org.Resource.HandlePutByID(w, r)
+	case http.MethodPatch:
+		org.Resource.HandlePatchByID(w, r)
+	case http.MethodDelete:
+		org.Resource.HandleDeleteByID(w, r)
 	default:
 		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
 	}
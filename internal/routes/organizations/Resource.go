@@ -0,0 +1,44 @@
+package organizations
+
+import (
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+)
+
+type OrganizationCreateItem struct {
+	Name         string                 `json:"name"`
+	OwnerID      *int                   `json:"owner_id,omitempty"`
+	OwnerEmail   string                 `json:"owner_email,omitempty"`
+	VisibleTo    *int                   `json:"visible_to,omitempty"`
+	Address      string                 `json:"address,omitempty"`
+	Label        string                 `json:"label,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Resource define o CRUD de /organizations
+var Resource = &resource.Resource[models.Organization, OrganizationCreateItem]{
+	Name:       "organizations",
+	Singular:   "organization",
+	Path:       "/organizations",
+	FieldsPath: "/organizationFields",
+	Required:   []string{"name"},
+	ReadOnly: map[string]bool{
+		"owner_id":   true,
+		"cc_email":   true,
+		"label_ids":  true,
+		"visible_to": true,
+	},
+	OwnerField: "owner_id",
+	DryRun:     true,
+	UpsertKeys: map[string]string{
+		"name":    "name",
+		"address": "address",
+	},
+	ExampleSingle: map[string]string{
+		"name": "Setup Tecnologia LTDA",
+	},
+	ExampleBulk: []map[string]string{
+		{"name": "Empresa A"},
+		{"name": "Empresa B"},
+	},
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"net/http"
+
+	person "pipedrive_api_service/internal/routes/persons"
+	"pipedrive_api_service/internal/utils"
+)
+
+func PersonsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		person.Resource.HandleGet(w, r)
+	case http.MethodPut:
+		person.Resource.HandlePut(w, r)
+	case http.MethodPost:
+		person.Resource.HandlePost(w, r)
+	case http.MethodDelete:
+		person.Resource.HandleDelete(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
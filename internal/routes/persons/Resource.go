@@ -0,0 +1,47 @@
+package persons
+
+import (
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+)
+
+type PersonCreateItem struct {
+	Name         string                 `json:"name"`
+	OwnerID      *int                   `json:"owner_id,omitempty"`
+	OrgID        *int                   `json:"org_id,omitempty"`
+	Email        []models.ContactInfo   `json:"email,omitempty"`
+	Phone        []models.ContactInfo   `json:"phone,omitempty"`
+	VisibleTo    *int                   `json:"visible_to,omitempty"`
+	Label        *int                   `json:"label,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Resource define o CRUD de /persons
+var Resource = &resource.Resource[models.Person, PersonCreateItem]{
+	Name:       "persons",
+	Singular:   "person",
+	Path:       "/persons",
+	FieldsPath: "/personFields",
+	Required:   []string{"name"},
+	ReadOnly: map[string]bool{
+		"owner_id":    true,
+		"cc_email":    true,
+		"add_time":    true,
+		"update_time": true,
+		"visible_to":  true,
+	},
+	// Campos de contato armazenados como lista ({value, primary, label})
+	ListFields: map[string]bool{
+		"email": true,
+		"phone": true,
+	},
+	LocalFilter: true,
+	ExampleSingle: map[string]interface{}{
+		"name":  "Maria Silva",
+		"email": []map[string]interface{}{{"value": "maria@empresa.com", "primary": true}},
+	},
+	ExampleBulk: []map[string]string{
+		{"name": "Contato A"},
+		{"name": "Contato B"},
+	},
+}
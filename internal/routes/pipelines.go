@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"pipedrive_api_service/internal/client"
 	"pipedrive_api_service/internal/models"
@@ -13,6 +14,8 @@ import (
 )
 
 func pipelinesUpstreamCall(ctx context.Context, c *client.PipedriveClient, query url.Values, dataContainer interface{}) (*utils.RateLimitInfo, int, error) {
+	includeStages := hasInclude(query, "stages")
+	query.Del("include")
 
 	resp, body, rate, err := c.Do(ctx, utils.HTTPGet, "/pipelines", query)
 
@@ -31,9 +34,44 @@ func pipelinesUpstreamCall(ctx context.Context, c *client.PipedriveClient, query
 		return rate, http.StatusInternalServerError, fmt.Errorf("failed to parse upstream response: %w", err)
 	}
 
+	if includeStages {
+		// Uma única chamada a /stages cobre todos os pipelines
+		allStages, stageRate, status, err := fetchStages(ctx, c, "")
+		if stageRate != nil {
+			rate = stageRate
+		}
+		if err != nil {
+			return rate, status, fmt.Errorf("failed to fetch stages: %w", err)
+		}
+
+		byPipeline := make(map[int][]models.Stage)
+		for _, s := range allStages {
+			byPipeline[s.PipelineID] = append(byPipeline[s.PipelineID], s)
+		}
+		for i := range pipedriveResponse.Data {
+			stagesOfPipeline := byPipeline[pipedriveResponse.Data[i].ID]
+			if stagesOfPipeline == nil {
+				stagesOfPipeline = []models.Stage{}
+			}
+			pipedriveResponse.Data[i].Stages = stagesOfPipeline
+		}
+	}
+
 	return rate, http.StatusOK, nil
 }
 
+// hasInclude verifica se ?include= (lista separada por vírgula) contém o valor
+func hasInclude(query url.Values, value string) bool {
+	for _, raw := range query["include"] {
+		for _, part := range strings.Split(raw, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func PipelinesHandler(w http.ResponseWriter, r *http.Request) {
 	envelope := &models.PipelinesResponse{}
 	HandlerWrapper(pipelinesUpstreamCall, envelope, "/pipelines")(w, r)
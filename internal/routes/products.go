@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"net/http"
+
+	product "pipedrive_api_service/internal/routes/products"
+	"pipedrive_api_service/internal/utils"
+)
+
+func ProductsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		product.Resource.HandleGet(w, r)
+	case http.MethodPut:
+		product.Resource.HandlePut(w, r)
+	case http.MethodPost:
+		product.Resource.HandlePost(w, r)
+	case http.MethodDelete:
+		product.Resource.HandleDelete(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
@@ -0,0 +1,46 @@
+package products
+
+import (
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+)
+
+type ProductCreateItem struct {
+	Name         string                 `json:"name"`
+	Code         string                 `json:"code,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	Unit         string                 `json:"unit,omitempty"`
+	Tax          *float64               `json:"tax,omitempty"`
+	Category     *int                   `json:"category,omitempty"`
+	OwnerID      *int                   `json:"owner_id,omitempty"`
+	VisibleTo    *int                   `json:"visible_to,omitempty"`
+	ActiveFlag   *bool                  `json:"active_flag,omitempty"`
+	Selectable   *bool                  `json:"selectable,omitempty"`
+	Prices       []models.ProductPrice  `json:"prices,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Resource define o CRUD de /products
+var Resource = &resource.Resource[models.Product, ProductCreateItem]{
+	Name:       "products",
+	Singular:   "product",
+	Path:       "/products",
+	FieldsPath: "/productFields",
+	Required:   []string{"name"},
+	ReadOnly: map[string]bool{
+		"owner_id":    true,
+		"first_char":  true,
+		"add_time":    true,
+		"update_time": true,
+		"visible_to":  true,
+	},
+	ExampleSingle: map[string]interface{}{
+		"name":   "Licença Anual",
+		"code":   "LIC-001",
+		"prices": []map[string]interface{}{{"currency": "BRL", "price": 1200}},
+	},
+	ExampleBulk: []map[string]string{
+		{"name": "Produto A"},
+		{"name": "Produto B"},
+	},
+}
@@ -0,0 +1,155 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/utils"
+)
+
+// RawPathRules controla quais caminhos do Pipedrive podem ser acessados via
+// /pipedrive/raw/. Regras terminadas em "*" casam por prefixo; as demais casam
+// o caminho exato e seus subcaminhos. Deny tem precedência; com Allow vazio,
+// nenhum caminho é permitido (o passthrough usa o token da conta).
+type RawPathRules struct {
+	Allow []string
+	Deny  []string
+}
+
+var (
+	rawRulesMu sync.RWMutex
+	rawRules   RawPathRules
+)
+
+// SetRawPathRules registra as regras de allow/deny lidas na inicialização
+func SetRawPathRules(rules RawPathRules) {
+	rawRulesMu.Lock()
+	rawRules = RawPathRules{
+		Allow: normalizeRawRules(rules.Allow),
+		Deny:  normalizeRawRules(rules.Deny),
+	}
+	rawRulesMu.Unlock()
+}
+
+func normalizeRawRules(rules []string) []string {
+	out := make([]string, 0, len(rules))
+	for _, r := range rules {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if !strings.HasPrefix(r, "/") {
+			r = "/" + r
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func matchRawRule(rule, p string) bool {
+	if strings.HasSuffix(rule, "*") {
+		return strings.HasPrefix(p, strings.TrimSuffix(rule, "*"))
+	}
+	rule = strings.TrimSuffix(rule, "/")
+	return p == rule || strings.HasPrefix(p, rule+"/")
+}
+
+func rawPathAllowed(p string) bool {
+	rawRulesMu.RLock()
+	defer rawRulesMu.RUnlock()
+
+	for _, rule := range rawRules.Deny {
+		if matchRawRule(rule, p) {
+			return false
+		}
+	}
+	for _, rule := range rawRules.Allow {
+		if matchRawRule(rule, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// RawHandler repassa qualquer método, query e corpo para o caminho informado
+// em /pipedrive/raw/{path...}, passando pelo broker (pausa global e backoff).
+func RawHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	rawPath := r.PathValue("path")
+	if strings.Contains(rawPath, "..") {
+		utils.JSONError(w, http.StatusBadRequest, "invalid path", nil)
+		return
+	}
+	upstreamPath := path.Clean("/" + rawPath)
+	if upstreamPath == "/" {
+		utils.JSONError(w, http.StatusBadRequest, "upstream path is required: /pipedrive/raw/{path}", nil)
+		return
+	}
+
+	if !rawPathAllowed(upstreamPath) {
+		utils.JSONError(w, http.StatusForbidden, map[string]interface{}{
+			"message": fmt.Sprintf("path '%s' is not allowed by the raw passthrough rules", upstreamPath),
+			"hint":    "add the path to PIPEDRIVE_RAW_ALLOW (e.g. /currencies or /goals*)",
+		}, nil)
+		return
+	}
+
+	method := utils.HTTPMethod(r.Method)
+	switch method {
+	case utils.HTTPGet, utils.HTTPPost, utils.HTTPPut, utils.HTTPPatch, utils.HTTPDelete:
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+		return
+	}
+
+	query := r.URL.Query()
+	query.Del("api_token")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	c := client.NewPipedriveClient()
+	resp, body, rate, err := c.DoWithBody(ctx, method, upstreamPath, query, r.Body)
+
+	upstreamStatus := http.StatusServiceUnavailable
+	if resp != nil {
+		upstreamStatus = resp.StatusCode
+	}
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+upstreamPath,
+		upstreamStatus,
+		rate,
+	)
+
+	if err != nil {
+		utils.JSONError(w, http.StatusServiceUnavailable, err.Error(), meta)
+		return
+	}
+	defer resp.Body.Close()
+
+	var data interface{} = string(body)
+	if json.Valid(body) {
+		data = json.RawMessage(body)
+	}
+
+	if upstreamStatus >= 400 {
+		utils.JSONError(w, upstreamStatus, data, meta)
+		return
+	}
+	utils.JSON(w, upstreamStatus, utils.Envelope{
+		Success:  true,
+		Data:     data,
+		Metadata: []utils.MetaItem{*meta},
+	})
+}
@@ -19,7 +19,10 @@ type ResponseDataEnvelope interface {
 	SetDataSlice(interface{})
 }
 
-func HandlerWrapper(callFunc UpstreamCallFunc, dataEnvelope ResponseDataEnvelope, endpointPath string) http.HandlerFunc {
+// HandlerWrapper executa a chamada upstream e aplica os filtros locais.
+// Parâmetros listados em upstreamParams são resolvidos pelo Pipedrive e
+// por isso não são reaplicados na filtragem local.
+func HandlerWrapper(callFunc UpstreamCallFunc, dataEnvelope ResponseDataEnvelope, endpointPath string, upstreamParams ...string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
@@ -44,8 +47,14 @@ func HandlerWrapper(callFunc UpstreamCallFunc, dataEnvelope ResponseDataEnvelope
 		)
 
 		if err != nil {
-			meta.Status = http.StatusServiceUnavailable
-			utils.JSONError(w, http.StatusServiceUnavailable, err.Error(), meta)
+			// Erros de parâmetro (4xx) voltam com o próprio status; os demais
+			// indicam falha ao falar com o Pipedrive
+			status := http.StatusServiceUnavailable
+			if upstreamStatus >= 400 && upstreamStatus < 500 {
+				status = upstreamStatus
+			}
+			meta.Status = status
+			utils.JSONError(w, status, err.Error(), meta)
 			return
 		}
 
@@ -55,8 +64,12 @@ func HandlerWrapper(callFunc UpstreamCallFunc, dataEnvelope ResponseDataEnvelope
 		}
 
 		// 1. Filtragem de dados local (e.g., ?name=Setup)
+		localQuery := r.URL.Query()
+		for _, p := range upstreamParams {
+			localQuery.Del(p)
+		}
 		dataSlice := dataEnvelope.GetDataSlice()
-		filtered, filterErr := utils.FilterSliceByQuery(dataSlice, r.URL.Query())
+		filtered, filterErr := utils.FilterSliceByQuery(dataSlice, localQuery)
 		if filterErr != nil {
 			utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply local filter: %v", filterErr), meta)
 			return
@@ -0,0 +1,168 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/utils"
+)
+
+const SearchPageLimit = 100
+
+// Tipos aceitos em ?item_types= (subconjunto modelado pelo proxy)
+var searchItemTypes = map[string]bool{
+	"deal":         true,
+	"person":       true,
+	"organization": true,
+	"product":      true,
+	"lead":         true,
+}
+
+// buildSearchQuery valida os parâmetros do proxy e monta a query do /itemSearch
+func buildSearchQuery(query url.Values) (url.Values, error) {
+	term := strings.TrimSpace(query.Get("term"))
+	exact := false
+	if v := query.Get("exact_match"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for 'exact_match': %s", v)
+		}
+		exact = parsed
+	}
+
+	// O Pipedrive exige ao menos 2 caracteres (1 com exact_match)
+	if len([]rune(term)) < 2 && !(exact && term != "") {
+		return nil, fmt.Errorf("query parameter 'term' must have at least 2 characters (1 with exact_match=true)")
+	}
+
+	upstream := url.Values{}
+	upstream.Set("term", term)
+	if exact {
+		upstream.Set("exact_match", "true")
+	}
+
+	if v := query.Get("item_types"); v != "" {
+		types := make([]string, 0)
+		for _, t := range strings.Split(v, ",") {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t == "" {
+				continue
+			}
+			if !searchItemTypes[t] {
+				return nil, fmt.Errorf("unsupported item type '%s': use deal, person, organization, product or lead", t)
+			}
+			types = append(types, t)
+		}
+		upstream.Set("item_types", strings.Join(types, ","))
+	}
+
+	// Aqui 'fields' define os campos pesquisados (e não a seleção de campos do retorno)
+	for _, key := range []string{"fields", "include_fields"} {
+		if v := query.Get(key); v != "" {
+			upstream.Set(key, v)
+		}
+	}
+
+	limit := SearchPageLimit
+	if v := query.Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid value for 'limit': %s", v)
+		}
+		limit = parsed
+	}
+	upstream.Set("limit", strconv.Itoa(limit))
+
+	start := 0
+	if v := query.Get("start"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid value for 'start': %s", v)
+		}
+		start = parsed
+	} else if v := query.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err == nil && page > 0 {
+			start = (page - 1) * limit
+		}
+	}
+	upstream.Set("start", strconv.Itoa(start))
+
+	return upstream, nil
+}
+
+func searchUpstreamCall(ctx context.Context, c *client.PipedriveClient, query url.Values, result *models.SearchResponse) (*utils.RateLimitInfo, int, error) {
+	resp, body, rate, err := c.Do(ctx, utils.HTTPGet, "/itemSearch", query)
+	if err != nil {
+		return rate, http.StatusServiceUnavailable, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rate, resp.StatusCode, fmt.Errorf("upstream returned status: %s", resp.Status)
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return rate, http.StatusInternalServerError, fmt.Errorf("failed to parse upstream response: %w", err)
+	}
+	return rate, http.StatusOK, nil
+}
+
+// SearchHandler busca deals, pessoas, organizações, produtos e leads de uma
+// só vez via /itemSearch, sem paginar a conta inteira.
+func SearchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+		return
+	}
+
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	upstreamQuery, err := buildSearchQuery(r.URL.Query())
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	result := &models.SearchResponse{}
+	rate, upstreamStatus, err := searchUpstreamCall(ctx, c, upstreamQuery, result)
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/itemSearch",
+		upstreamStatus,
+		rate,
+	)
+
+	if err != nil {
+		utils.JSONError(w, upstreamStatus, err.Error(), meta)
+		return
+	}
+
+	items := result.Data.Items
+	if items == nil {
+		items = []models.SearchResultItem{}
+	}
+
+	pagination := result.AdditionalData.Pagination
+	meta.Extra = &utils.ExtraMeta{
+		TotalResults:          len(items),
+		MoreItemsInCollection: pagination.MoreItemsInCollection,
+		NextStart:             pagination.NextStart,
+	}
+
+	utils.JSONOK(w, items, meta)
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/routes/stages"
+	"pipedrive_api_service/internal/utils"
+)
+
+const StagesPageLimit = 500
+
+// fetchStages busca as etapas no Pipedrive, opcionalmente restritas a um pipeline
+func fetchStages(ctx context.Context, c *client.PipedriveClient, pipelineID string) ([]models.Stage, *utils.RateLimitInfo, int, error) {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%d", StagesPageLimit))
+	if pipelineID != "" {
+		query.Set("pipeline_id", pipelineID)
+	}
+
+	resp, body, rate, err := c.Do(ctx, utils.HTTPGet, "/stages", query)
+	if err != nil {
+		return nil, rate, http.StatusServiceUnavailable, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, rate, resp.StatusCode, fmt.Errorf("upstream returned status: %s", resp.Status)
+	}
+
+	var parsed models.StagesResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, rate, http.StatusInternalServerError, fmt.Errorf("failed to parse upstream response: %w", err)
+	}
+	if parsed.Data == nil {
+		parsed.Data = []models.Stage{}
+	}
+	return parsed.Data, rate, http.StatusOK, nil
+}
+
+func stagesUpstreamCall(ctx context.Context, c *client.PipedriveClient, query url.Values, dataContainer interface{}) (*utils.RateLimitInfo, int, error) {
+	finalResponse := dataContainer.(*models.StagesResponse)
+
+	data, rate, status, err := fetchStages(ctx, c, query.Get("pipeline_id"))
+	if err != nil {
+		return rate, status, err
+	}
+	finalResponse.Data = data
+	return rate, status, nil
+}
+
+func StagesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		envelope := &models.StagesResponse{}
+		HandlerWrapper(stagesUpstreamCall, envelope, "/stages", "pipeline_id")(w, r)
+	case http.MethodPost:
+		stages.HandlePost(w, r)
+	case http.MethodPut:
+		stages.HandlePut(w, r)
+	case http.MethodDelete:
+		stages.HandleDelete(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
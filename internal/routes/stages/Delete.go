@@ -1,4 +1,4 @@
-package organizations
+package stages
 
 import (
 	"context"
@@ -10,11 +10,16 @@ import (
 	"time"
 
 	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/resource"
 	"pipedrive_api_service/internal/utils"
 )
 
-// HandleDelete removes one or multiple organizations by ID
+// HandleDelete removes one or multiple stages by ID
 func HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
 	start := time.Now()
 	c := client.NewPipedriveClient()
 
@@ -31,7 +36,7 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 	if len(bodyRaw) == 0 {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
 			"message":        "request body is empty",
-			"hint":           "provide at least one organization ID to delete",
+			"hint":           "provide at least one stage ID to delete",
 			"example_single": map[string]int{"id": 123},
 			"example_bulk":   []int{123, 124, 125},
 		}, nil)
@@ -60,7 +65,7 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 
 	if len(ids) == 0 {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
-			"message": "no valid organization IDs found in body",
+			"message": "no valid stage IDs found in body",
 			"hint":    "body must contain a numeric 'id' or a list of IDs",
 		}, nil)
 		return
@@ -79,8 +84,8 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		path := fmt.Sprintf("/organizations/%d", id)
-		reqCtx, cancel := context.WithTimeout(r.Context(), 8*time.Second)
+		path := fmt.Sprintf("/stages/%d", id)
+		reqCtx, cancel := context.WithTimeout(r.Context(), resource.DefaultTimeout)
 		resp, body, _, err := c.Do(reqCtx, utils.HTTPDelete, path, nil)
 		cancel()
 
@@ -91,7 +96,7 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
-		defer resp.Body.Close()
+		resp.Body.Close()
 
 		if resp.StatusCode >= 400 {
 			var parsed map[string]interface{}
@@ -111,17 +116,12 @@ func HandleDelete(w http.ResponseWriter, r *http.Request) {
 		successCount++
 	}
 
-	finalStatus := "success"
-	if successCount == 0 {
-		finalStatus = "failure"
-	} else if successCount < len(ids) {
-		finalStatus = "partial_failure"
-	}
+	finalStatus := resource.BulkStatus(successCount, len(ids))
 
 	meta := utils.NewMetaItem(
 		start,
 		r.Header.Get(utils.HeaderXRequestID),
-		c.BaseURL()+"/organizations (delete bulk)",
+		c.BaseURL()+"/stages (delete bulk)",
 		http.StatusOK,
 		nil,
 	)
@@ -1,4 +1,4 @@
-package organizations
+package stages
 
 import (
 	"bytes"
@@ -11,23 +11,26 @@ import (
 	"time"
 
 	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/resource"
 	"pipedrive_api_service/internal/utils"
 )
 
-type OrganizationCreateItem struct {
-	Name         string                 `json:"name"`
-	OwnerID      *int                   `json:"owner_id,omitempty"`
-	VisibleTo    *int                   `json:"visible_to,omitempty"`
-	Address      string                 `json:"address,omitempty"`
-	Label        string                 `json:"label,omitempty"`
-	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+type StageCreateItem struct {
+	Name            string `json:"name"`
+	PipelineID      *int   `json:"pipeline_id,omitempty"`
+	DealProbability *int   `json:"deal_probability,omitempty"`
+	RottenFlag      *bool  `json:"rotten_flag,omitempty"`
+	RottenDays      *int   `json:"rotten_days,omitempty"`
 }
 
 func HandlePost(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
 	start := time.Now()
 	c := client.NewPipedriveClient()
 
-	// Leitura bruta do body
 	bodyRaw, err := io.ReadAll(r.Body)
 	if err != nil {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
@@ -38,33 +41,31 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
-	// Detecta vazio
 	if len(bodyRaw) == 0 {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
 			"message": "request body is empty",
-			"hint":    "provide at least one organization to create",
+			"hint":    "provide at least one stage to create",
 		}, nil)
 		return
 	}
 
-	var items []OrganizationCreateItem
-
-	// 1. Tenta decodificar como lista
+	var items []StageCreateItem
 	if err := json.Unmarshal(bodyRaw, &items); err != nil {
-		// 2. Tenta como único
-		var single OrganizationCreateItem
+		var single StageCreateItem
 		if err2 := json.Unmarshal(bodyRaw, &single); err2 == nil && strings.TrimSpace(single.Name) != "" {
 			items = append(items, single)
 		} else {
 			utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
 				"message": "invalid JSON format",
 				"hint":    "body must be either a single object or an array of objects",
-				"example_single": map[string]string{
-					"name": "Setup Tecnologia LTDA",
+				"example_single": map[string]interface{}{
+					"name":             "Proposta enviada",
+					"pipeline_id":      1,
+					"deal_probability": 60,
 				},
-				"example_bulk": []map[string]string{
-					{"name": "Empresa A"},
-					{"name": "Empresa B"},
+				"example_bulk": []map[string]interface{}{
+					{"name": "Qualificação", "pipeline_id": 1},
+					{"name": "Negociação", "pipeline_id": 1, "rotten_flag": true, "rotten_days": 15},
 				},
 			}, nil)
 			return
@@ -73,7 +74,7 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 
 	if len(items) == 0 {
 		utils.JSONError(w, http.StatusBadRequest, map[string]interface{}{
-			"message": "no valid organizations found in body",
+			"message": "no valid stages found in body",
 			"hint":    "check your JSON structure and required fields",
 		}, nil)
 		return
@@ -92,30 +93,40 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
+		if item.PipelineID == nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  "field 'pipeline_id' is required",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
 
 		payload := map[string]interface{}{
-			"name": item.Name,
-		}
-		if item.OwnerID != nil {
-			payload["owner_id"] = *item.OwnerID
+			"name":        item.Name,
+			"pipeline_id": *item.PipelineID,
 		}
-		if item.VisibleTo != nil {
-			payload["visible_to"] = *item.VisibleTo
+		if item.DealProbability != nil {
+			payload["deal_probability"] = *item.DealProbability
 		}
-		if item.Address != "" {
-			payload["address"] = item.Address
+		if item.RottenFlag != nil {
+			payload["rotten_flag"] = *item.RottenFlag
 		}
-		if item.Label != "" {
-			payload["label"] = item.Label
+		if item.RottenDays != nil {
+			payload["rotten_days"] = *item.RottenDays
 		}
-		for k, v := range item.CustomFields {
-			payload[k] = v
+
+		if validationErr := validateStage(payload); validationErr != nil {
+			results[indexKey] = map[string]interface{}{
+				"error":  validationErr.Error(),
+				"status": http.StatusBadRequest,
+			}
+			continue
 		}
 
 		bodyBytes, _ := json.Marshal(payload)
 
-		reqCtx, cancel := context.WithTimeout(r.Context(), 8*time.Second)
-		resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPost, "/organizations", nil, bytes.NewReader(bodyBytes))
+		reqCtx, cancel := context.WithTimeout(r.Context(), resource.DefaultTimeout)
+		resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPost, "/stages", nil, bytes.NewReader(bodyBytes))
 		cancel()
 
 		if err != nil || resp == nil {
@@ -125,11 +136,13 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
-		defer resp.Body.Close()
+		resp.Body.Close()
 
 		if resp.StatusCode >= 400 {
+			var parsed map[string]interface{}
+			_ = json.Unmarshal(body, &parsed)
 			results[indexKey] = map[string]interface{}{
-				"error":  fmt.Sprintf("upstream returned %d", resp.StatusCode),
+				"error":  resource.ExtractUpstreamError(parsed),
 				"status": resp.StatusCode,
 			}
 			continue
@@ -155,17 +168,12 @@ func HandlePost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	finalStatus := "success"
-	if success == 0 {
-		finalStatus = "failure"
-	} else if success < len(items) {
-		finalStatus = "partial_failure"
-	}
+	finalStatus := resource.BulkStatus(success, len(items))
 
 	meta := utils.NewMetaItem(
 		start,
 		r.Header.Get(utils.HeaderXRequestID),
-		c.BaseURL()+"/organizations (create bulk)",
+		c.BaseURL()+"/stages (create bulk)",
 		http.StatusCreated,
 		nil,
 	)
@@ -0,0 +1,182 @@
+package stages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/resource"
+	"pipedrive_api_service/internal/utils"
+)
+
+type StageUpdateItem struct {
+	Verbose bool                   `json:"verbose,omitempty"`
+	ID      int                    `json:"id"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Campos bloqueados para edição direta
+var readOnlyFields = map[string]bool{
+	"pipeline_name": true,
+	"add_time":      true,
+	"update_time":   true,
+}
+
+func HandlePut(w http.ResponseWriter, r *http.Request) {
+	if resource.RejectAsync(w, r) {
+		return
+	}
+
+	start := time.Now()
+
+	var items []StageUpdateItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid JSON body", nil)
+		return
+	}
+	if len(items) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, "empty update list", nil)
+		return
+	}
+
+	c := client.NewPipedriveClient()
+	results := make(map[string]interface{}, len(items))
+	success := 0
+
+	for _, it := range items {
+		idStr := strconv.Itoa(it.ID)
+
+		if it.ID <= 0 {
+			results[idStr] = map[string]interface{}{
+				"error":  "invalid id",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if len(it.Fields) == 0 {
+			results[idStr] = map[string]interface{}{
+				"error":  "fields required for update",
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+		if validationErr := validateFields(it.Fields); validationErr != nil {
+			results[idStr] = map[string]interface{}{
+				"error":  validationErr.Error(),
+				"status": http.StatusBadRequest,
+			}
+			continue
+		}
+
+		res, status, err := doUpdate(r.Context(), c, it.ID, it.Fields, it.Verbose)
+		if err != nil {
+			results[idStr] = map[string]interface{}{
+				"error":  err.Error(),
+				"status": status,
+			}
+			continue
+		}
+		results[idStr] = res
+		success++
+	}
+
+	finalStatus := resource.BulkStatus(success, len(items))
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/stages (bulk)",
+		http.StatusMultiStatus,
+		nil,
+	)
+
+	utils.JSONOK(w, models.BulkUpdateResult{
+		Status:  finalStatus,
+		Results: results,
+	}, meta)
+}
+
+// --- Helpers ---
+
+func doUpdate(ctx context.Context, c *client.PipedriveClient, id int, fields map[string]interface{}, verbose bool) (interface{}, int, error) {
+	bodyBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("marshal fields: %w", err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, resource.DefaultTimeout)
+	defer cancel()
+
+	resp, body, _, err := c.DoWithBody(reqCtx, utils.HTTPPut, "/stages/"+strconv.Itoa(id), nil, bytes.NewReader(bodyBytes))
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		return nil, status, fmt.Errorf("update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed map[string]interface{}
+	_ = json.Unmarshal(body, &parsed)
+
+	if resp.StatusCode >= 400 {
+		return parsed, resp.StatusCode, errors.New(resource.ExtractUpstreamError(parsed))
+	}
+
+	if !verbose {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		return map[string]interface{}{
+			"success":        true,
+			"fields_altered": keys,
+		}, resp.StatusCode, nil
+	}
+	return parsed["data"], resp.StatusCode, nil
+}
+
+func validateFields(fields map[string]interface{}) error {
+	for k := range fields {
+		if readOnlyFields[k] {
+			return fmt.Errorf("field '%s' is read-only and cannot be modified", k)
+		}
+	}
+	return validateStage(fields)
+}
+
+// validateStage valida ordem, probabilidade e dias para "apodrecer" do deal
+func validateStage(fields map[string]interface{}) error {
+	if v, ok := fields["name"]; ok && strings.TrimSpace(fmt.Sprint(v)) == "" {
+		return fmt.Errorf("field 'name' cannot be empty")
+	}
+	for _, k := range []string{"order_nr", "rotten_days", "pipeline_id"} {
+		if v, ok := fields[k]; ok {
+			n, err := strconv.Atoi(fmt.Sprint(v))
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid numeric value for '%s'", k)
+			}
+		}
+	}
+	if v, ok := fields["deal_probability"]; ok {
+		n, err := strconv.Atoi(fmt.Sprint(v))
+		if err != nil || n < 0 || n > 100 {
+			return fmt.Errorf("invalid value for 'deal_probability': must be between 0 and 100")
+		}
+	}
+	if v, ok := fields["rotten_flag"]; ok {
+		if _, isBool := v.(bool); !isBool {
+			return fmt.Errorf("invalid value for 'rotten_flag': must be a boolean")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"net/http"
+
+	"pipedrive_api_service/internal/routes/users"
+	"pipedrive_api_service/internal/utils"
+)
+
+func UsersHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		users.HandleGet(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+func UsersMeHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		users.HandleMe(w, r)
+	default:
+		utils.JSONError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
@@ -0,0 +1,107 @@
+package users
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	userdir "pipedrive_api_service/internal/users"
+	"pipedrive_api_service/internal/utils"
+)
+
+// HandleGet lista usuários (com filtros locais e seleção de campos) ou busca
+// por ID com ?id=1,2. Use ?refresh=true para ignorar o cache.
+func HandleGet(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+	query := r.URL.Query()
+
+	force, _ := strconv.ParseBool(query.Get("refresh"))
+	query.Del("refresh")
+
+	users, rate, upstreamStatus, err := userdir.Fetch(r.Context(), c, force)
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/users",
+		upstreamStatus,
+		rate,
+	)
+
+	if err != nil {
+		utils.JSONError(w, upstreamStatus, err.Error(), meta)
+		return
+	}
+
+	if id := query.Get("id"); id != "" {
+		query.Del("id")
+		wanted := make(map[int]bool)
+		for _, part := range strings.Split(id, ",") {
+			if n, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+				wanted[n] = true
+			}
+		}
+		selected := make([]models.User, 0, len(wanted))
+		for _, u := range users {
+			if wanted[u.ID] {
+				selected = append(selected, u)
+			}
+		}
+		if len(selected) == 0 {
+			meta.Status = http.StatusNotFound
+			utils.JSONError(w, http.StatusNotFound, "no users found for the provided IDs", meta)
+			return
+		}
+		users = selected
+	}
+
+	filtered, filterErr := utils.FilterSliceByQuery(users, query)
+	if filterErr != nil {
+		utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply local filter: %v", filterErr), meta)
+		return
+	}
+
+	finalData, fieldFilterErr := utils.FilterFieldsByQuery(filtered, query)
+	if fieldFilterErr != nil {
+		utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to apply field filter: %v", fieldFilterErr), meta)
+		return
+	}
+
+	total := 0
+	switch v := finalData.(type) {
+	case []models.User:
+		total = len(v)
+	case []map[string]interface{}:
+		total = len(v)
+	}
+	meta.Extra = &utils.ExtraMeta{TotalResults: total}
+	utils.JSONOK(w, finalData, meta)
+}
+
+// HandleMe retorna o usuário dono do token de API
+func HandleMe(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	c := client.NewPipedriveClient()
+
+	force, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+	me, rate, upstreamStatus, err := userdir.FetchMe(r.Context(), c, force)
+
+	meta := utils.NewMetaItem(
+		start,
+		r.Header.Get(utils.HeaderXRequestID),
+		c.BaseURL()+"/users/me",
+		upstreamStatus,
+		rate,
+	)
+
+	if err != nil {
+		utils.JSONError(w, upstreamStatus, err.Error(), meta)
+		return
+	}
+	utils.JSONOK(w, me, meta)
+}
@@ -22,6 +22,9 @@ type Task struct {
 	MaxAttempts int
 	respCh      chan taskResult
 	createdAt   time.Time
+	walID       string // set when the task is recorded in the WAL
+	sent        bool
+	resumed     bool // recovered from the WAL by Resume
 }
 
 type taskResult struct {
@@ -40,6 +43,7 @@ type UpstreamBroker struct {
 	mu         sync.Mutex
 	pauseUntil time.Time
 	lastRate   *utils.RateLimitInfo
+	wal        *WAL
 }
 
 // NewUpstreamBroker creates a broker with worker pool and bounded queue.
@@ -60,12 +64,26 @@ func NewUpstreamBroker(workers int, queueSize int) *UpstreamBroker {
 	return b
 }
 
-// Stop gracefully stops workers.
+// Stop gracefully stops workers. Queued writes stay unacknowledged in the
+// WAL (if any) and are replayed on the next start.
 func (b *UpstreamBroker) Stop() {
 	close(b.quit)
 	b.wg.Wait()
 }
 
+// SetWAL enables the write-ahead log for mutating tasks.
+func (b *UpstreamBroker) SetWAL(w *WAL) {
+	b.mu.Lock()
+	b.wal = w
+	b.mu.Unlock()
+}
+
+func (b *UpstreamBroker) currentWAL() *WAL {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.wal
+}
+
 // Execute enqueues a task and waits for result or ctx cancellation.
 func (b *UpstreamBroker) Execute(ctx context.Context, method, url string, headers map[string]string, body []byte, maxAttempts int) (*http.Response, []byte, *utils.RateLimitInfo, error) {
 	if maxAttempts <= 0 {
@@ -81,11 +99,48 @@ func (b *UpstreamBroker) Execute(ctx context.Context, method, url string, header
 		respCh:      make(chan taskResult, 1),
 		createdAt:   time.Now(),
 	}
+	if wal := b.currentWAL(); wal != nil && isMutating(method) {
+		t.walID = newTaskID()
+		wal.queued(t, OriginFrom(ctx))
+	}
+	return b.submit(ctx, t)
+}
+
+// Resume re-enqueues a write recovered from the WAL under its original id.
+// 'url' is the pending task URL with the api_token added back.
+func (b *UpstreamBroker) Resume(ctx context.Context, p PendingTask, url string) (*http.Response, []byte, *utils.RateLimitInfo, error) {
+	t := &Task{
+		Method:      p.Method,
+		URL:         url,
+		Headers:     p.Headers,
+		Body:        p.Body,
+		MaxAttempts: 3,
+		respCh:      make(chan taskResult, 1),
+		createdAt:   time.Now(),
+		walID:       p.ID,
+		sent:        p.Sent,
+		resumed:     true,
+	}
+	return b.submit(ctx, t)
+}
+
+// Discard acknowledges a recovered write without sending it.
+func (b *UpstreamBroker) Discard(id string) {
+	if wal := b.currentWAL(); wal != nil {
+		wal.done(id)
+	}
+}
 
+func (b *UpstreamBroker) submit(ctx context.Context, t *Task) (*http.Response, []byte, *utils.RateLimitInfo, error) {
 	// Try to enqueue but respect caller context.
 	select {
 	case b.queue <- t:
 	case <-ctx.Done():
+		// never queued: a new write has nothing to replay, a resumed one
+		// stays in the WAL
+		if !t.resumed {
+			b.ack(t)
+		}
 		return nil, nil, nil, ctx.Err()
 	}
 
@@ -117,7 +172,7 @@ func (b *UpstreamBroker) workerLoop() {
 				continue
 			}
 			if !b.waitIfNotPaused() {
-				// quit signaled
+				// quit signaled; the task stays in the WAL (if any) for replay
 				task.respCh <- taskResult{nil, nil, nil, errors.New("broker shutting down")}
 				continue
 			}
@@ -155,13 +210,20 @@ func (b *UpstreamBroker) processTask(t *Task) {
 	}
 	req, err := http.NewRequest(t.Method, t.URL, bodyReader)
 	if err != nil {
-		t.respCh <- taskResult{nil, nil, nil, err}
+		b.finish(t, taskResult{nil, nil, nil, err})
 		return
 	}
 	for k, v := range t.Headers {
 		req.Header.Set(k, v)
 	}
 
+	if t.walID != "" && !t.sent {
+		t.sent = true
+		if wal := b.currentWAL(); wal != nil {
+			wal.sent(t.walID)
+		}
+	}
+
 	// execute request
 	resp, err := b.client.Do(req)
 	if err != nil {
@@ -171,7 +233,7 @@ func (b *UpstreamBroker) processTask(t *Task) {
 			go b.requeueWithBackoff(t, t.Attempts)
 			return
 		}
-		t.respCh <- taskResult{nil, nil, nil, err}
+		b.finish(t, taskResult{nil, nil, nil, err})
 		return
 	}
 
@@ -203,12 +265,12 @@ func (b *UpstreamBroker) processTask(t *Task) {
 				select {
 				case b.queue <- tt:
 				default:
-					tt.respCh <- taskResult{nil, nil, rate, errors.New("queue full while re-enqueue")}
+					b.finish(tt, taskResult{nil, nil, rate, errors.New("queue full while re-enqueue")})
 				}
 			}(t, wait)
 			return
 		}
-		t.respCh <- taskResult{nil, bodyBytes, rate, errors.New("max attempts reached after 429")}
+		b.finish(t, taskResult{nil, bodyBytes, rate, errors.New("max attempts reached after 429")})
 		return
 	}
 
@@ -229,7 +291,22 @@ func (b *UpstreamBroker) processTask(t *Task) {
 		Request:       resp.Request,
 	}
 
-	t.respCh <- taskResult{respCopy, bodyBytes, rate, nil}
+	b.finish(t, taskResult{respCopy, bodyBytes, rate, nil})
+}
+
+// finish delivers the final result and acknowledges the task in the WAL
+func (b *UpstreamBroker) finish(t *Task, res taskResult) {
+	b.ack(t)
+	t.respCh <- res
+}
+
+func (b *UpstreamBroker) ack(t *Task) {
+	if t.walID == "" {
+		return
+	}
+	if wal := b.currentWAL(); wal != nil {
+		wal.done(t.walID)
+	}
 }
 
 func (b *UpstreamBroker) setPause(t time.Time) {
@@ -249,7 +326,7 @@ func (b *UpstreamBroker) requeueWithBackoff(t *Task, attempt int) {
 	select {
 	case b.queue <- t:
 	default:
-		t.respCh <- taskResult{nil, nil, nil, errors.New("queue full while retry")}
+		b.finish(t, taskResult{nil, nil, nil, errors.New("queue full while retry")})
 	}
 }
 
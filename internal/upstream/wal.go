@@ -0,0 +1,492 @@
+package upstream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TaskOrigin identifies where a queued write came from, so that writes
+// replayed after a restart can be grouped back into jobs.
+type TaskOrigin struct {
+	JobID          string `json:"job_id,omitempty"`
+	Operation      string `json:"operation,omitempty"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	Fingerprint    string `json:"fingerprint,omitempty"`
+	ItemKey        string `json:"item_key,omitempty"`
+}
+
+type originKey struct{}
+
+// WithOrigin returns a context whose origin has the non-empty fields of 'o'
+// over the origin already in ctx.
+func WithOrigin(ctx context.Context, o TaskOrigin) context.Context {
+	cur := OriginFrom(ctx)
+	if o.JobID != "" {
+		cur.JobID = o.JobID
+	}
+	if o.Operation != "" {
+		cur.Operation = o.Operation
+	}
+	if o.IdempotencyKey != "" {
+		cur.IdempotencyKey = o.IdempotencyKey
+		cur.Fingerprint = o.Fingerprint
+	}
+	if o.ItemKey != "" {
+		cur.ItemKey = o.ItemKey
+	}
+	return context.WithValue(ctx, originKey{}, cur)
+}
+
+// OriginFrom returns the origin stored in ctx (zero value if none).
+func OriginFrom(ctx context.Context) TaskOrigin {
+	o, _ := ctx.Value(originKey{}).(TaskOrigin)
+	return o
+}
+
+const (
+	walQueued  = "queued"   // task accepted by the broker
+	walSent    = "sent"     // request written to the wire at least once
+	walDone    = "done"     // final result delivered (or task discarded)
+	walJob     = "job"      // async job accepted, with the keys of its items
+	walItem    = "item"     // one item of an async job finished
+	walJobDone = "job_done" // async job finished or cancelled by the user
+)
+
+// walCompactEvery is how many acknowledged records the log accumulates
+// before it is rewritten with only the pending entries.
+const walCompactEvery = 1000
+
+type walRecord struct {
+	Op      string            `json:"op"`
+	ID      string            `json:"id"`
+	Method  string            `json:"method,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    []byte            `json:"body,omitempty"`
+	Origin  *TaskOrigin       `json:"origin,omitempty"`
+	Items   []string          `json:"items,omitempty"`
+	Item    string            `json:"item,omitempty"`
+	OK      bool              `json:"ok,omitempty"`
+	At      time.Time         `json:"at"`
+}
+
+// PendingTask is a write found in the log without a final result.
+type PendingTask struct {
+	ID        string
+	Method    string
+	URL       string // without api_token
+	Headers   map[string]string
+	Body      []byte
+	Origin    TaskOrigin
+	Sent      bool // the request may already have reached Pipedrive
+	CreatedAt time.Time
+}
+
+// PendingJob is an async job found in the log without a final record.
+type PendingJob struct {
+	ID     string
+	Origin TaskOrigin
+	// Items holds the keys of every item of the job, in submission order
+	Items []string
+	// Finished holds the items that finished before the restart (true when
+	// they succeeded)
+	Finished  map[string]bool
+	CreatedAt time.Time
+}
+
+// Backlog is what a previous run left unfinished in the log.
+type Backlog struct {
+	Tasks []PendingTask
+	Jobs  []PendingJob
+}
+
+// Empty reports whether there is nothing to recover.
+func (b Backlog) Empty() bool { return len(b.Tasks) == 0 && len(b.Jobs) == 0 }
+
+// walState is the set of pending entries described by the log, kept in the
+// order they were recorded.
+type walState struct {
+	next  int
+	seq   map[string]int // "task:<id>" or "job:<id>"
+	tasks map[string]*PendingTask
+	jobs  map[string]*PendingJob
+}
+
+func newWALState() *walState {
+	return &walState{
+		seq:   map[string]int{},
+		tasks: map[string]*PendingTask{},
+		jobs:  map[string]*PendingJob{},
+	}
+}
+
+func (s *walState) apply(rec walRecord) {
+	switch rec.Op {
+	case walQueued:
+		t := &PendingTask{
+			ID:        rec.ID,
+			Method:    rec.Method,
+			URL:       rec.URL,
+			Headers:   rec.Headers,
+			Body:      rec.Body,
+			CreatedAt: rec.At,
+		}
+		if rec.Origin != nil {
+			t.Origin = *rec.Origin
+		}
+		s.tasks[rec.ID] = t
+		s.seq["task:"+rec.ID] = s.next
+		s.next++
+	case walSent:
+		if t, ok := s.tasks[rec.ID]; ok {
+			t.Sent = true
+		}
+	case walDone:
+		delete(s.tasks, rec.ID)
+		delete(s.seq, "task:"+rec.ID)
+	case walJob:
+		j := &PendingJob{
+			ID:        rec.ID,
+			Items:     rec.Items,
+			Finished:  map[string]bool{},
+			CreatedAt: rec.At,
+		}
+		if rec.Origin != nil {
+			j.Origin = *rec.Origin
+		}
+		s.jobs[rec.ID] = j
+		s.seq["job:"+rec.ID] = s.next
+		s.next++
+	case walItem:
+		if j, ok := s.jobs[rec.ID]; ok {
+			j.Finished[rec.Item] = rec.OK
+		}
+	case walJobDone:
+		delete(s.jobs, rec.ID)
+		delete(s.seq, "job:"+rec.ID)
+	}
+}
+
+func (s *walState) empty() bool { return len(s.tasks) == 0 && len(s.jobs) == 0 }
+
+func (s *walState) backlog() Backlog {
+	var b Backlog
+	for _, key := range s.ordered() {
+		kind, id, _ := strings.Cut(key, ":")
+		if kind == "task" {
+			b.Tasks = append(b.Tasks, *s.tasks[id])
+			continue
+		}
+		j := *s.jobs[id]
+		j.Finished = make(map[string]bool, len(j.Finished))
+		for item, ok := range s.jobs[id].Finished {
+			j.Finished[item] = ok
+		}
+		b.Jobs = append(b.Jobs, j)
+	}
+	return b
+}
+
+// ordered returns the keys of the pending entries in recording order
+func (s *walState) ordered() []string {
+	keys := make([]string, 0, len(s.seq))
+	for key := range s.seq {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return s.seq[keys[i]] < s.seq[keys[j]] })
+	return keys
+}
+
+// records returns the minimal log that rebuilds the state
+func (s *walState) records() []walRecord {
+	recs := make([]walRecord, 0, len(s.seq))
+	for _, key := range s.ordered() {
+		kind, id, _ := strings.Cut(key, ":")
+		if kind == "task" {
+			t := s.tasks[id]
+			origin := t.Origin
+			recs = append(recs, walRecord{Op: walQueued, ID: t.ID, Method: t.Method, URL: t.URL, Headers: t.Headers, Body: t.Body, Origin: &origin, At: t.CreatedAt})
+			if t.Sent {
+				recs = append(recs, walRecord{Op: walSent, ID: t.ID, At: t.CreatedAt})
+			}
+			continue
+		}
+		j := s.jobs[id]
+		origin := j.Origin
+		recs = append(recs, walRecord{Op: walJob, ID: j.ID, Origin: &origin, Items: j.Items, At: j.CreatedAt})
+		for _, item := range j.Items {
+			if ok, finished := j.Finished[item]; finished {
+				recs = append(recs, walRecord{Op: walItem, ID: j.ID, Item: item, OK: ok, At: j.CreatedAt})
+			}
+		}
+	}
+	return recs
+}
+
+// WAL is an append-only, file-backed log of mutating broker tasks and async
+// jobs. The pending entries are also kept in memory, so that the file can be
+// compacted while the service runs.
+type WAL struct {
+	mu    sync.Mutex
+	path  string
+	f     *os.File
+	state *walState
+	// acked counts the entries finished since the last compaction
+	acked int
+	// written counts the records appended; synced is the last one known to
+	// be on disk (guarded by syncMu)
+	written uint64
+	syncMu  sync.Mutex
+	synced  uint64
+}
+
+// OpenWAL opens (or creates) the log at 'path' and returns the writes and
+// jobs that a previous run left unfinished. The file is compacted to hold
+// only those entries.
+func OpenWAL(path string) (*WAL, Backlog, error) {
+	state, err := readWAL(path)
+	if err != nil {
+		return nil, Backlog{}, err
+	}
+	if err := writeWALFile(path, state.records()); err != nil {
+		return nil, Backlog{}, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, Backlog{}, fmt.Errorf("open wal: %w", err)
+	}
+	return &WAL{path: path, f: f, state: state}, state.backlog(), nil
+}
+
+func readWAL(path string) (*walState, error) {
+	state := newWALState()
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open wal: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var rec walRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			// a crash can leave the last line half written
+			log.Printf("wal: skipping unreadable line %d of %s: %v", line, path, err)
+			continue
+		}
+		state.apply(rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read wal: %w", err)
+	}
+	return state, nil
+}
+
+// writeWALFile replaces the log with 'recs' (temp file + rename)
+func writeWALFile(path string, recs []walRecord) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range recs {
+		_ = enc.Encode(rec)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("compact wal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("compact wal: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("compact wal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("compact wal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("compact wal: %w", err)
+	}
+	return nil
+}
+
+// Close closes the log file.
+func (w *WAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
+
+// durable reports whether the caller must wait for the record to reach the
+// disk: a write is only queued, sent or accepted as a job once its record is
+// synced. Acknowledgements are not synced on their own; losing one on a crash
+// only means the entry is recovered again.
+func durable(op string) bool {
+	switch op {
+	case walQueued, walSent, walJob:
+		return true
+	}
+	return false
+}
+
+func (w *WAL) append(rec walRecord) {
+	rec.At = time.Now()
+	line, err := json.Marshal(rec)
+	if err != nil {
+		log.Printf("wal: encode %s %s: %v", rec.Op, rec.ID, err)
+		return
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	w.state.apply(rec)
+	if _, err := w.f.Write(line); err != nil {
+		w.mu.Unlock()
+		log.Printf("wal: write %s %s: %v", rec.Op, rec.ID, err)
+		return
+	}
+	w.written++
+	seq := w.written
+	if rec.Op == walDone || rec.Op == walJobDone {
+		w.acked++
+		w.compactLocked()
+	}
+	w.mu.Unlock()
+
+	if durable(rec.Op) {
+		w.syncTo(seq, rec)
+	}
+}
+
+// syncTo waits until the record 'seq' is on disk. The fsync runs outside
+// w.mu, and one call covers every record written before it: callers that
+// queue up behind it find their record already synced (group commit).
+func (w *WAL) syncTo(seq uint64, rec walRecord) {
+	w.syncMu.Lock()
+	defer w.syncMu.Unlock()
+	if w.synced >= seq {
+		return
+	}
+
+	w.mu.Lock()
+	f, target := w.f, w.written
+	w.mu.Unlock()
+
+	// a compaction that replaced (and closed) f has already synced these
+	// records in the new file
+	if err := f.Sync(); err != nil && !errors.Is(err, os.ErrClosed) {
+		log.Printf("wal: sync %s %s: %v", rec.Op, rec.ID, err)
+		return
+	}
+	w.synced = target
+}
+
+// compactLocked empties the file when nothing is pending and rewrites it with
+// only the pending entries after walCompactEvery acknowledged ones.
+func (w *WAL) compactLocked() {
+	if w.state.empty() {
+		if err := w.f.Truncate(0); err != nil {
+			log.Printf("wal: truncate %s: %v", w.path, err)
+			return
+		}
+		w.acked = 0
+		return
+	}
+	if w.acked < walCompactEvery {
+		return
+	}
+	if err := writeWALFile(w.path, w.state.records()); err != nil {
+		log.Printf("wal: %v", err)
+		return
+	}
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		// keep appending to the old (unlinked) file until the next attempt
+		log.Printf("wal: reopen %s: %v", w.path, err)
+		return
+	}
+	w.f.Close()
+	w.f = f
+	w.acked = 0
+}
+
+func (w *WAL) queued(t *Task, origin TaskOrigin) {
+	w.append(walRecord{
+		Op:      walQueued,
+		ID:      t.walID,
+		Method:  t.Method,
+		URL:     redactToken(t.URL),
+		Headers: t.Headers,
+		Body:    t.Body,
+		Origin:  &origin,
+	})
+}
+
+func (w *WAL) sent(id string) { w.append(walRecord{Op: walSent, ID: id}) }
+
+func (w *WAL) done(id string) { w.append(walRecord{Op: walDone, ID: id}) }
+
+// JobQueued records an async job and the keys of its items, so that items
+// that never ran can be reported after a restart.
+func (w *WAL) JobQueued(origin TaskOrigin, items []string) {
+	w.append(walRecord{Op: walJob, ID: origin.JobID, Origin: &origin, Items: items})
+}
+
+// JobItem records that one item of the job finished.
+func (w *WAL) JobItem(jobID, item string, ok bool) {
+	w.append(walRecord{Op: walItem, ID: jobID, Item: item, OK: ok})
+}
+
+// JobDone records that the job finished and no longer needs recovery.
+func (w *WAL) JobDone(jobID string) { w.append(walRecord{Op: walJobDone, ID: jobID}) }
+
+// isMutating reports whether a method changes data upstream and must be logged
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// redactToken removes api_token from the URL; it is added back on replay
+func redactToken(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	q := u.Query()
+	if q.Get("api_token") == "" {
+		return raw
+	}
+	q.Del("api_token")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func newTaskID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
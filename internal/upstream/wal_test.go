@@ -0,0 +1,311 @@
+package upstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func writeLog(t *testing.T, recs ...walRecord) string {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range recs {
+		if err := enc.Encode(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "wal.log")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func pendingIDs(tasks []PendingTask) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Count(data, []byte("\n"))
+}
+
+func TestReadWALSequences(t *testing.T) {
+	path := writeLog(t,
+		walRecord{Op: walQueued, ID: "a", Method: "PUT", URL: "/deals/1"},
+		walRecord{Op: walQueued, ID: "b", Method: "POST", URL: "/deals"},
+		walRecord{Op: walSent, ID: "b"},
+		walRecord{Op: walQueued, ID: "c", Method: "DELETE", URL: "/deals/2"},
+		walRecord{Op: walSent, ID: "c"},
+		walRecord{Op: walDone, ID: "c"},
+		walRecord{Op: walQueued, ID: "d", Method: "PUT", URL: "/deals/3"},
+		walRecord{Op: walDone, ID: "d"},
+		// registros de tarefas desconhecidas são ignorados
+		walRecord{Op: walSent, ID: "x"},
+		walRecord{Op: walDone, ID: "y"},
+	)
+
+	state, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks := state.backlog().Tasks
+	if got := pendingIDs(tasks); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("pending = %v; want [a b]", got)
+	}
+	if tasks[0].Sent || !tasks[1].Sent {
+		t.Fatalf("sent flags = %v, %v; want false, true", tasks[0].Sent, tasks[1].Sent)
+	}
+}
+
+func TestReadWALTruncatedLastLine(t *testing.T) {
+	path := writeLog(t,
+		walRecord{Op: walQueued, ID: "a", Method: "PUT", URL: "/deals/1"},
+		walRecord{Op: walQueued, ID: "b", Method: "PUT", URL: "/deals/2"},
+	)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// o "done" de 'a' ficou pela metade no crash
+	if _, err := f.WriteString(`{"op":"done","id":"a","at":"20`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	state, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pendingIDs(state.backlog().Tasks); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("pending = %v; want [a b]", got)
+	}
+}
+
+func TestReadWALMissingFile(t *testing.T) {
+	state, err := readWAL(filepath.Join(t.TempDir(), "missing.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.backlog().Empty() {
+		t.Fatal("missing file must have an empty backlog")
+	}
+}
+
+func TestReadWALJobs(t *testing.T) {
+	path := writeLog(t,
+		walRecord{Op: walJob, ID: "job_a", Origin: &TaskOrigin{JobID: "job_a", Operation: "PUT /pipedrive/deals"}, Items: []string{"1", "2", "3"}},
+		walRecord{Op: walItem, ID: "job_a", Item: "1", OK: true},
+		walRecord{Op: walItem, ID: "job_a", Item: "2"},
+		walRecord{Op: walJob, ID: "job_b", Items: []string{"0"}},
+		walRecord{Op: walJobDone, ID: "job_b"},
+	)
+
+	state, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs := state.backlog().Jobs
+	if len(jobs) != 1 || jobs[0].ID != "job_a" {
+		t.Fatalf("jobs = %+v; want only job_a", jobs)
+	}
+	if jobs[0].Origin.Operation != "PUT /pipedrive/deals" {
+		t.Fatalf("origin = %+v", jobs[0].Origin)
+	}
+	want := map[string]bool{"1": true, "2": false}
+	if !reflect.DeepEqual(jobs[0].Finished, want) {
+		t.Fatalf("finished = %v; want %v", jobs[0].Finished, want)
+	}
+}
+
+func TestOpenWALCompactionRoundTrip(t *testing.T) {
+	path := writeLog(t,
+		walRecord{Op: walQueued, ID: "a", Method: "PUT", URL: "/deals/1", Body: []byte(`{"title":"A"}`), Origin: &TaskOrigin{JobID: "job_a", ItemKey: "1"}},
+		walRecord{Op: walJob, ID: "job_a", Origin: &TaskOrigin{JobID: "job_a"}, Items: []string{"1", "2"}},
+		walRecord{Op: walItem, ID: "job_a", Item: "2", OK: true},
+		walRecord{Op: walQueued, ID: "b", Method: "POST", URL: "/deals"},
+		walRecord{Op: walSent, ID: "b"},
+		walRecord{Op: walQueued, ID: "c", Method: "PUT", URL: "/deals/3"},
+		walRecord{Op: walDone, ID: "c"},
+	)
+	before, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, backlog, err := OpenWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if !reflect.DeepEqual(backlog, before.backlog()) {
+		t.Fatalf("OpenWAL backlog = %+v; want %+v", backlog, before.backlog())
+	}
+	// queued a, job, item, queued b, sent b
+	if n := countLines(t, path); n != 5 {
+		t.Fatalf("compacted log has %d lines; want 5", n)
+	}
+
+	after, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(after.backlog(), backlog) {
+		t.Fatalf("after compaction = %+v; want %+v", after.backlog(), backlog)
+	}
+}
+
+func TestWALTruncatesWhenIdle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal.log")
+	w, _, err := OpenWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	task := &Task{Method: "PUT", URL: "https://api.example.com/deals/1?api_token=secret", walID: "a"}
+	w.queued(task, TaskOrigin{})
+	w.sent("a")
+	if n := countLines(t, path); n != 2 {
+		t.Fatalf("log has %d lines; want 2", n)
+	}
+	data, _ := os.ReadFile(path)
+	if bytes.Contains(data, []byte("secret")) {
+		t.Fatal("api_token must not be written to the log")
+	}
+
+	w.done("a")
+	if n := countLines(t, path); n != 0 {
+		t.Fatalf("log has %d lines after the last ack; want 0", n)
+	}
+
+	// o arquivo continua utilizável depois de esvaziado
+	w.queued(&Task{Method: "DELETE", URL: "/deals/2", walID: "b"}, TaskOrigin{})
+	state, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pendingIDs(state.backlog().Tasks); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Fatalf("pending = %v; want [b]", got)
+	}
+}
+
+func TestWALCompactsWhileRunning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal.log")
+	w, _, err := OpenWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// uma escrita pendente impede o esvaziamento; as demais são confirmadas
+	w.queued(&Task{Method: "PUT", URL: "/deals/1", walID: "pending"}, TaskOrigin{})
+	for i := 0; i < walCompactEvery; i++ {
+		w.queued(&Task{Method: "PUT", URL: "/deals/2", walID: "acked"}, TaskOrigin{})
+		w.done("acked")
+	}
+
+	if n := countLines(t, path); n != 1 {
+		t.Fatalf("log has %d lines after compaction; want 1", n)
+	}
+	state, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pendingIDs(state.backlog().Tasks); !reflect.DeepEqual(got, []string{"pending"}) {
+		t.Fatalf("pending = %v; want [pending]", got)
+	}
+
+	// novas linhas vão para o arquivo compactado
+	w.sent("pending")
+	state, err = readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tasks := state.backlog().Tasks; len(tasks) != 1 || !tasks[0].Sent {
+		t.Fatalf("pending = %+v; want the task marked as sent", tasks)
+	}
+}
+
+func TestWALJobRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal.log")
+	w, _, err := OpenWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	w.JobQueued(TaskOrigin{JobID: "job_a", IdempotencyKey: "k1", Fingerprint: "fp"}, []string{"0", "1"})
+	w.JobItem("job_a", "0", true)
+
+	state, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs := state.backlog().Jobs
+	if len(jobs) != 1 || jobs[0].Origin.IdempotencyKey != "k1" || !reflect.DeepEqual(jobs[0].Items, []string{"0", "1"}) {
+		t.Fatalf("jobs = %+v", jobs)
+	}
+	if ok, finished := jobs[0].Finished["0"]; !finished || !ok {
+		t.Fatalf("finished = %v; want item 0 as a success", jobs[0].Finished)
+	}
+
+	w.JobDone("job_a")
+	if n := countLines(t, path); n != 0 {
+		t.Fatalf("log has %d lines after the job finished; want 0", n)
+	}
+}
+
+func TestWALGroupCommit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal.log")
+	w, _, err := OpenWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// escritas concorrentes: cada 'queued' só retorna depois de sincronizado
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			w.queued(&Task{Method: "PUT", URL: "/deals/" + id, walID: id}, TaskOrigin{})
+			w.JobItem("job_x", id, true)
+		}(strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	// confirmações não esperam o fsync; o último 'queued' cobre as anteriores
+	w.syncMu.Lock()
+	synced := w.synced
+	w.syncMu.Unlock()
+	if synced < 50 {
+		t.Fatalf("synced = %d; want at least the 50 queued records", synced)
+	}
+	if n := countLines(t, path); n != 100 {
+		t.Fatalf("log has %d lines; want 100", n)
+	}
+	state, err := readWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(state.backlog().Tasks); got != 50 {
+		t.Fatalf("pending = %d; want 50", got)
+	}
+}
@@ -0,0 +1,199 @@
+// Package users mantém o cache de usuários do Pipedrive, usado pela rota
+// /pipedrive/users e pela resolução de 'owner_email' nos recursos.
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"pipedrive_api_service/internal/client"
+	"pipedrive_api_service/internal/models"
+	"pipedrive_api_service/internal/utils"
+)
+
+// CacheTTL define por quanto tempo a lista de usuários é reaproveitada
+const CacheTTL = 5 * time.Minute
+
+// MinRefreshInterval é o intervalo mínimo entre recargas forçadas por
+// ResolveOwnerEmail (emails desconhecidos não disparam uma recarga cada)
+const MinRefreshInterval = 30 * time.Second
+
+// loadTimeout limita a carga compartilhada por todas as requisições que
+// esperam pela lista
+const loadTimeout = 30 * time.Second
+
+// userLoad é uma carga em andamento; quem chega durante a carga espera por
+// ela em vez de disparar outra (single-flight)
+type userLoad struct {
+	done   chan struct{}
+	users  []models.User
+	rate   *utils.RateLimitInfo
+	status int
+	err    error
+}
+
+// userCache guarda a lista de usuários e o usuário do token (/users/me),
+// compartilhados pela rota /pipedrive/users e pelos recursos que resolvem
+// 'owner_email'.
+type userCache struct {
+	mu       sync.Mutex
+	users    []models.User
+	loadedAt time.Time
+	loading  *userLoad
+	me       *models.User
+	meAt     time.Time
+}
+
+var cache = &userCache{}
+
+// Fetch retorna os usuários do cache ou os recarrega do Pipedrive quando o
+// cache expirou ou force=true. Recargas simultâneas são compartilhadas.
+func Fetch(ctx context.Context, c *client.PipedriveClient, force bool) ([]models.User, *utils.RateLimitInfo, int, error) {
+	cache.mu.Lock()
+	if !force && cache.users != nil && time.Since(cache.loadedAt) < CacheTTL {
+		users := cache.users
+		cache.mu.Unlock()
+		return users, nil, http.StatusOK, nil
+	}
+	load := cache.loading
+	if load == nil {
+		load = &userLoad{done: make(chan struct{})}
+		cache.loading = load
+		go loadUsers(c, load)
+	}
+	cache.mu.Unlock()
+
+	select {
+	case <-load.done:
+		return load.users, load.rate, load.status, load.err
+	case <-ctx.Done():
+		return nil, nil, http.StatusGatewayTimeout, ctx.Err()
+	}
+}
+
+// loadUsers carrega a lista fora do contexto da requisição, para que o
+// cancelamento de um cliente não afete os demais que esperam pela carga
+func loadUsers(c *client.PipedriveClient, load *userLoad) {
+	ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+	defer cancel()
+
+	load.users, load.rate, load.status, load.err = requestUsers(ctx, c)
+
+	cache.mu.Lock()
+	if load.err == nil {
+		cache.users = load.users
+		cache.loadedAt = time.Now()
+	}
+	cache.loading = nil
+	cache.mu.Unlock()
+	close(load.done)
+}
+
+func requestUsers(ctx context.Context, c *client.PipedriveClient) ([]models.User, *utils.RateLimitInfo, int, error) {
+	resp, body, rate, err := c.Do(ctx, utils.HTTPGet, "/users", nil)
+	if err != nil {
+		return nil, rate, http.StatusServiceUnavailable, fmt.Errorf("failed to fetch users: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, rate, resp.StatusCode, fmt.Errorf("upstream returned %d while fetching users", resp.StatusCode)
+	}
+
+	var parsed models.UsersResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, rate, http.StatusInternalServerError, fmt.Errorf("failed to parse users: %w", err)
+	}
+	if parsed.Data == nil {
+		parsed.Data = []models.User{}
+	}
+	return parsed.Data, rate, http.StatusOK, nil
+}
+
+// FetchMe retorna o usuário dono do token de API
+func FetchMe(ctx context.Context, c *client.PipedriveClient, force bool) (*models.User, *utils.RateLimitInfo, int, error) {
+	cache.mu.Lock()
+	if !force && cache.me != nil && time.Since(cache.meAt) < CacheTTL {
+		me := cache.me
+		cache.mu.Unlock()
+		return me, nil, http.StatusOK, nil
+	}
+	cache.mu.Unlock()
+
+	resp, body, rate, err := c.Do(ctx, utils.HTTPGet, "/users/me", nil)
+	if err != nil {
+		return nil, rate, http.StatusServiceUnavailable, fmt.Errorf("failed to fetch current user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, rate, resp.StatusCode, fmt.Errorf("upstream returned %d while fetching current user", resp.StatusCode)
+	}
+
+	var parsed struct {
+		Success bool        `json:"success"`
+		Data    models.User `json:"data"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, rate, http.StatusInternalServerError, fmt.Errorf("failed to parse current user: %w", err)
+	}
+
+	cache.mu.Lock()
+	cache.me = &parsed.Data
+	cache.meAt = time.Now()
+	cache.mu.Unlock()
+
+	return &parsed.Data, rate, http.StatusOK, nil
+}
+
+// canForceRefresh indica se a última carga é antiga o bastante para uma
+// recarga forçada
+func canForceRefresh() bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return time.Since(cache.loadedAt) >= MinRefreshInterval
+}
+
+func findActive(users []models.User, email string) (int, bool) {
+	for _, u := range users {
+		if u.ActiveFlag && strings.EqualFold(u.Email, email) {
+			return u.ID, true
+		}
+	}
+	return 0, false
+}
+
+// ResolveOwnerEmail converte o email de um usuário ativo no seu ID.
+// Se o email não estiver no cache, a lista é recarregada uma vez antes de
+// desistir (o usuário pode ter sido criado depois do último carregamento);
+// essa recarga acontece no máximo uma vez a cada MinRefreshInterval.
+func ResolveOwnerEmail(ctx context.Context, c *client.PipedriveClient, email string) (int, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return 0, fmt.Errorf("field 'owner_email' cannot be empty")
+	}
+
+	users, _, _, err := Fetch(ctx, c, false)
+	if err != nil {
+		return 0, err
+	}
+	if id, ok := findActive(users, email); ok {
+		return id, nil
+	}
+
+	if canForceRefresh() {
+		users, _, _, err = Fetch(ctx, c, true)
+		if err != nil {
+			return 0, err
+		}
+		if id, ok := findActive(users, email); ok {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("no active user found with email '%s'", email)
+}
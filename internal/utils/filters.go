@@ -132,11 +132,39 @@ func matchField(fv reflect.Value, raw string, exact bool) bool {
 			return got.Equal(t)
 		}
 		return false
+
+	case reflect.Slice:
+		// Listas (e.g. email/phone de pessoas) casam se qualquer item casar
+		for i := 0; i < fv.Len(); i++ {
+			if matchField(sliceElemValue(fv.Index(i)), raw, exact) {
+				return true
+			}
+		}
+		return false
 	default:
 		return false
 	}
 }
 
+// sliceElemValue retorna o campo 'value' quando o item da lista é uma struct
+// no formato do Pipedrive ({value, primary, label}); caso contrário o próprio item.
+func sliceElemValue(elem reflect.Value) reflect.Value {
+	if elem.Kind() == reflect.Pointer && !elem.IsNil() {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return elem
+	}
+	typ := elem.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		tagName := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if tagName == "value" {
+			return elem.Field(i)
+		}
+	}
+	return elem
+}
+
 func FilterFieldsByQuery(items interface{}, q url.Values) (interface{}, error) {
 	fieldsStr := q.Get("fields")
 	if fieldsStr == "" || strings.EqualFold(fieldsStr, "all") {
@@ -20,7 +20,12 @@ const (
 	HeaderXRateLimitRemaining = "X-RateLimit-Remaining"
 	HeaderXRateLimitLimit     = "X-RateLimit-Limit"
 	HeaderXRateLimitReset     = "X-RateLimit-Reset"
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotentReplayed  = "Idempotent-Replayed"
+	HeaderLocation            = "Location"
 	ContentTypeJSON           = "application/json"
 	ContentTypeFormURLEncoded = "application/x-www-form-urlencoded"
 	ContentTypeOctetStream    = "application/octet-stream"
+	ContentTypeMergePatch     = "application/merge-patch+json"
+	ContentTypeJSONPatch      = "application/json-patch+json"
 )
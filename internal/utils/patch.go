@@ -0,0 +1,284 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MergePatch aplica um JSON Merge Patch (RFC 7396) sobre 'target'.
+// Valores null no patch removem a chave; objetos são mesclados recursivamente
+// e qualquer outro valor substitui o original.
+func MergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	out := make(map[string]interface{}, len(targetObj))
+	for k, v := range targetObj {
+		out[k] = v
+	}
+
+	for k, v := range patchObj {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = MergePatch(out[k], v)
+	}
+	return out
+}
+
+// JSONPatchOp é uma operação de JSON Patch (RFC 6902)
+type JSONPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ApplyJSONPatch aplica as operações em ordem sobre uma cópia de 'doc'.
+// Qualquer falha (inclusive um 'test' que não confere) invalida o patch inteiro.
+func ApplyJSONPatch(doc interface{}, ops []JSONPatchOp) (interface{}, error) {
+	out := deepCopyJSON(doc)
+	var err error
+
+	for i, op := range ops {
+		switch op.Op {
+		case "add":
+			out, err = pointerSet(out, op.Path, deepCopyJSON(op.Value), true)
+		case "remove":
+			out, _, err = pointerRemove(out, op.Path)
+		case "replace":
+			if _, err = pointerGet(out, op.Path); err == nil {
+				out, err = pointerSet(out, op.Path, deepCopyJSON(op.Value), false)
+			}
+		case "move":
+			if op.Path == op.From {
+				// mover para o mesmo lugar não altera o documento
+				_, err = pointerGet(out, op.From)
+				break
+			}
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				err = fmt.Errorf("cannot move '%s' into itself", op.From)
+				break
+			}
+			var moved interface{}
+			if out, moved, err = pointerRemove(out, op.From); err == nil {
+				out, err = pointerSet(out, op.Path, moved, true)
+			}
+		case "copy":
+			var copied interface{}
+			if copied, err = pointerGet(out, op.From); err == nil {
+				out, err = pointerSet(out, op.Path, deepCopyJSON(copied), true)
+			}
+		case "test":
+			var current interface{}
+			if current, err = pointerGet(out, op.Path); err == nil && !reflect.DeepEqual(current, op.Value) {
+				err = fmt.Errorf("test failed: value at '%s' does not match", op.Path)
+			}
+		default:
+			err = fmt.Errorf("unsupported op '%s'", op.Op)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return out, nil
+}
+
+// parsePointer converte um JSON Pointer (RFC 6901) em segmentos
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer '%s'", pointer)
+	}
+	parts := strings.Split(pointer[1:], "/")
+	for i, p := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+	}
+	return parts, nil
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index '%s'", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if idx > max {
+		return 0, fmt.Errorf("array index %d out of range", idx)
+	}
+	return idx, nil
+}
+
+func pointerGet(doc interface{}, pointer string) (interface{}, error) {
+	parts, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := doc
+	for _, token := range parts {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			v, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", pointer)
+			}
+			current = v
+		case []interface{}:
+			idx, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[idx]
+		default:
+			return nil, fmt.Errorf("path '%s' does not exist", pointer)
+		}
+	}
+	return current, nil
+}
+
+// pointerSet grava 'value' em 'pointer'. Com insert=true, índices de lista
+// inserem (e "-" acrescenta no fim); caso contrário substituem.
+func pointerSet(doc interface{}, pointer string, value interface{}, insert bool) (interface{}, error) {
+	parts, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return value, nil
+	}
+
+	parent, err := pointerGet(doc, pointerOf(parts[:len(parts)-1]))
+	if err != nil {
+		return nil, err
+	}
+	last := parts[len(parts)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return doc, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(node), insert)
+		if err != nil {
+			return nil, err
+		}
+		if !insert {
+			node[idx] = value
+			return doc, nil
+		}
+		grown := append(node[:idx:idx], append([]interface{}{value}, node[idx:]...)...)
+		return replaceAt(doc, parts[:len(parts)-1], grown)
+	default:
+		return nil, fmt.Errorf("path '%s' does not exist", pointer)
+	}
+}
+
+func pointerRemove(doc interface{}, pointer string) (interface{}, interface{}, error) {
+	parts, err := parsePointer(pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(parts) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the whole document")
+	}
+
+	parent, err := pointerGet(doc, pointerOf(parts[:len(parts)-1]))
+	if err != nil {
+		return nil, nil, err
+	}
+	last := parts[len(parts)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		removed, ok := node[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("path '%s' does not exist", pointer)
+		}
+		delete(node, last)
+		return doc, removed, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		removed := node[idx]
+		shrunk := append(node[:idx:idx], node[idx+1:]...)
+		doc, err = replaceAt(doc, parts[:len(parts)-1], shrunk)
+		return doc, removed, err
+	default:
+		return nil, nil, fmt.Errorf("path '%s' does not exist", pointer)
+	}
+}
+
+// replaceAt troca o nó em 'parts' (usado quando uma lista muda de tamanho)
+func replaceAt(doc interface{}, parts []string, value interface{}) (interface{}, error) {
+	if len(parts) == 0 {
+		return value, nil
+	}
+	return pointerSet(doc, pointerOf(parts), value, false)
+}
+
+func pointerOf(parts []string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+	escaped := make([]string, len(parts))
+	for i, p := range parts {
+		escaped[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~", "~0"), "/", "~1")
+	}
+	return "/" + strings.Join(escaped, "/")
+}
+
+func deepCopyJSON(v interface{}) interface{} {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(node))
+		for k, val := range node {
+			out[k] = deepCopyJSON(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(node))
+		for i, val := range node {
+			out[i] = deepCopyJSON(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// TopLevelChanges compara dois objetos JSON e retorna as chaves de primeiro
+// nível alteradas com o novo valor (null para chaves removidas)
+func TopLevelChanges(before, after map[string]interface{}) map[string]interface{} {
+	changes := map[string]interface{}{}
+	for k, v := range after {
+		if old, ok := before[k]; !ok || !reflect.DeepEqual(old, v) {
+			changes[k] = v
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			changes[k] = nil
+		}
+	}
+	return changes
+}
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", raw, err)
+	}
+	return v
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		ops     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "add object key",
+			doc:  `{"a": 1}`,
+			ops:  `[{"op": "add", "path": "/b", "value": 2}]`,
+			want: `{"a": 1, "b": 2}`,
+		},
+		{
+			name: "add inserts into array",
+			doc:  `{"list": [1, 3]}`,
+			ops:  `[{"op": "add", "path": "/list/1", "value": 2}]`,
+			want: `{"list": [1, 2, 3]}`,
+		},
+		{
+			name: "add with - appends",
+			doc:  `{"list": [1, 2]}`,
+			ops:  `[{"op": "add", "path": "/list/-", "value": 3}]`,
+			want: `{"list": [1, 2, 3]}`,
+		},
+		{
+			name:    "add past the end",
+			doc:     `{"list": [1]}`,
+			ops:     `[{"op": "add", "path": "/list/5", "value": 2}]`,
+			wantErr: true,
+		},
+		{
+			name:    "leading zero index",
+			doc:     `{"list": [1, 2]}`,
+			ops:     `[{"op": "replace", "path": "/list/01", "value": 9}]`,
+			wantErr: true,
+		},
+		{
+			name:    "- is not valid outside add",
+			doc:     `{"list": [1, 2]}`,
+			ops:     `[{"op": "remove", "path": "/list/-"}]`,
+			wantErr: true,
+		},
+		{
+			name: "remove object key",
+			doc:  `{"a": 1, "b": 2}`,
+			ops:  `[{"op": "remove", "path": "/b"}]`,
+			want: `{"a": 1}`,
+		},
+		{
+			name: "remove array element",
+			doc:  `{"list": [1, 2, 3]}`,
+			ops:  `[{"op": "remove", "path": "/list/0"}]`,
+			want: `{"list": [2, 3]}`,
+		},
+		{
+			name:    "remove missing key",
+			doc:     `{"a": 1}`,
+			ops:     `[{"op": "remove", "path": "/b"}]`,
+			wantErr: true,
+		},
+		{
+			name: "replace value",
+			doc:  `{"a": 1}`,
+			ops:  `[{"op": "replace", "path": "/a", "value": "x"}]`,
+			want: `{"a": "x"}`,
+		},
+		{
+			name:    "replace missing key",
+			doc:     `{"a": 1}`,
+			ops:     `[{"op": "replace", "path": "/b", "value": 2}]`,
+			wantErr: true,
+		},
+		{
+			name: "move between keys",
+			doc:  `{"a": {"x": 1}, "b": {}}`,
+			ops:  `[{"op": "move", "from": "/a/x", "path": "/b/y"}]`,
+			want: `{"a": {}, "b": {"y": 1}}`,
+		},
+		{
+			name:    "move into itself",
+			doc:     `{"a": {"x": 1}}`,
+			ops:     `[{"op": "move", "from": "/a", "path": "/a/x"}]`,
+			wantErr: true,
+		},
+		{
+			name: "move to the same path",
+			doc:  `{"a": {"x": 1}}`,
+			ops:  `[{"op": "move", "from": "/a", "path": "/a"}]`,
+			want: `{"a": {"x": 1}}`,
+		},
+		{
+			name:    "move from a missing path",
+			doc:     `{"a": 1}`,
+			ops:     `[{"op": "move", "from": "/b", "path": "/b"}]`,
+			wantErr: true,
+		},
+		{
+			name: "move to sibling with shared prefix",
+			doc:  `{"a": 1}`,
+			ops:  `[{"op": "move", "from": "/a", "path": "/ab"}]`,
+			want: `{"ab": 1}`,
+		},
+		{
+			name: "copy is independent of the source",
+			doc:  `{"a": {"x": 1}}`,
+			ops:  `[{"op": "copy", "from": "/a", "path": "/b"}, {"op": "replace", "path": "/b/x", "value": 2}]`,
+			want: `{"a": {"x": 1}, "b": {"x": 2}}`,
+		},
+		{
+			name: "test passes",
+			doc:  `{"a": [1, {"b": "c"}]}`,
+			ops:  `[{"op": "test", "path": "/a", "value": [1, {"b": "c"}]}]`,
+			want: `{"a": [1, {"b": "c"}]}`,
+		},
+		{
+			name:    "failed test invalidates the whole patch",
+			doc:     `{"a": 1}`,
+			ops:     `[{"op": "replace", "path": "/a", "value": 2}, {"op": "test", "path": "/a", "value": 1}]`,
+			wantErr: true,
+		},
+		{
+			name: "~1 and ~0 escaping",
+			doc:  `{"a/b": 1, "m~n": 2}`,
+			ops:  `[{"op": "replace", "path": "/a~1b", "value": 3}, {"op": "remove", "path": "/m~0n"}]`,
+			want: `{"a/b": 3}`,
+		},
+		{
+			name:    "pointer without leading slash",
+			doc:     `{"a": 1}`,
+			ops:     `[{"op": "replace", "path": "a", "value": 2}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported op",
+			doc:     `{"a": 1}`,
+			ops:     `[{"op": "increment", "path": "/a"}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := decodeJSON(t, tt.doc)
+			var ops []JSONPatchOp
+			if err := json.Unmarshal([]byte(tt.ops), &ops); err != nil {
+				t.Fatalf("invalid ops: %v", err)
+			}
+
+			got, err := ApplyJSONPatch(doc, ops)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				if !reflect.DeepEqual(doc, decodeJSON(t, tt.doc)) {
+					t.Fatalf("failed patch modified the original document: %v", doc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{"replace value", `{"a": 1}`, `{"a": 2}`, `{"a": 2}`},
+		{"add key", `{"a": 1}`, `{"b": 2}`, `{"a": 1, "b": 2}`},
+		{"null removes key", `{"a": 1, "b": 2}`, `{"b": null}`, `{"a": 1}`},
+		{"null on missing key", `{"a": 1}`, `{"b": null}`, `{"a": 1}`},
+		{"nested merge", `{"a": {"x": 1, "y": 2}}`, `{"a": {"y": null, "z": 3}}`, `{"a": {"x": 1, "z": 3}}`},
+		{"arrays are replaced", `{"a": [1, 2]}`, `{"a": [3]}`, `{"a": [3]}`},
+		{"object replaces scalar", `{"a": 1}`, `{"a": {"x": 1}}`, `{"a": {"x": 1}}`},
+		{"non-object patch replaces target", `{"a": 1}`, `[1]`, `[1]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := decodeJSON(t, tt.target)
+			got := MergePatch(target, decodeJSON(t, tt.patch))
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(target, decodeJSON(t, tt.target)) {
+				t.Fatalf("merge patch modified the target: %v", target)
+			}
+		})
+	}
+}
+
+func TestTopLevelChanges(t *testing.T) {
+	before := map[string]interface{}{"a": 1.0, "b": "x", "c": []interface{}{1.0}}
+	after := map[string]interface{}{"a": 1.0, "b": "y", "d": true}
+
+	got := TopLevelChanges(before, after)
+	want := map[string]interface{}{"b": "y", "c": nil, "d": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -20,7 +20,18 @@ type TokenUsage struct {
 }
 
 type ExtraMeta struct {
-	TotalResults int `json:"total_results,omitempty"`
+	TotalResults          int           `json:"total_results,omitempty"`
+	MoreItemsInCollection bool          `json:"more_items_in_collection,omitempty"`
+	NextStart             int           `json:"next_start,omitempty"`
+	Failures              []ItemFailure `json:"failures,omitempty"`
+	Warnings              []string      `json:"warnings,omitempty"`
+}
+
+// ItemFailure descreve um item que falhou em uma leitura de vários IDs
+type ItemFailure struct {
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+	Error  string `json:"error"`
 }
 
 type MetaItem struct {
@@ -31,6 +42,8 @@ type MetaItem struct {
 	RateLimit  *RateLimitInfo `json:"rate_limit,omitempty"`
 	Tokens     *TokenUsage    `json:"tokens,omitempty"`
 	Extra      *ExtraMeta     `json:"extra,omitempty"`
+	// FieldCacheAgeMs é a idade do cache de campos usado na resposta
+	FieldCacheAgeMs *int64 `json:"field_cache_age_ms,omitempty"`
 }
 
 type Envelope struct {